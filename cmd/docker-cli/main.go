@@ -2,7 +2,10 @@ package main
 
 import (
 	"context"
+	"errors"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/docker/docker/client"
 
@@ -13,7 +16,10 @@ import (
 )
 
 func main() {
-	ctx := context.Background()
+	// Long running commands such as logs --follow stop cleanly on Ctrl-C through the cancelled context.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	log := logger.NewLogger()
 
 	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
@@ -28,12 +34,25 @@ func main() {
 
 	startCmd := command.NewStartCommand(ctx, log, pr, dockerClient)
 	stopCmd := command.NewStopCommand(ctx, log, pr, dockerClient)
+	restartCmd := command.NewRestartCommand(ctx, log, pr, dockerClient)
+	psCmd := command.NewPsCommand(ctx, log, dockerClient)
+	logsCmd := command.NewLogsCommand(ctx, log, pr, dockerClient)
+	execCmd := command.NewExecCommand(ctx, log, dockerClient)
+	configCmd := command.NewConfigCommand(log)
+	validateCmd := command.NewValidateCommand(log)
+	pullCmd := command.NewPullCommand(ctx, log, pr, dockerClient)
 
 	rootCmd := command.NewRootCommand()
 	rootCmd.CompletionOptions.HiddenDefaultCmd = true
-	rootCmd.AddCommand(startCmd, stopCmd)
+	rootCmd.AddCommand(startCmd, stopCmd, restartCmd, psCmd, logsCmd, execCmd, configCmd, validateCmd, pullCmd)
 
 	if err = rootCmd.Execute(); err != nil {
+		stop()
+
+		var exitErr *command.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(1)
 	}
 }
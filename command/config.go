@@ -0,0 +1,144 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/petrovskiborislav/docker-cli/logger"
+	"github.com/petrovskiborislav/docker-cli/yaml"
+)
+
+const (
+	yamlFormat      = "yaml"
+	allServicesHash = "*"
+)
+
+// NewConfigCommand creates config command which prints the compose
+// file after interpolation, merging and normalization.
+func NewConfigCommand(logger logger.Logger) *cobra.Command {
+	compose := composeOptions{logger: logger}
+	var format string
+	var services, images bool
+	var hash string
+
+	cmd := &cobra.Command{
+		Use:           "config [PATH to docker-compose file]",
+		Short:         "Prints the resolved compose model of the specified compose file",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	cmd.RunE = func(_ *cobra.Command, args []string) error {
+		composeFile, err := compose.parseComposeFile(args)
+		if err != nil {
+			logger.Error("Error parsing compose file: %s\n", err)
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		switch {
+		case services:
+			err = printLines(out, sortedServiceNames(composeFile))
+		case images:
+			err = printLines(out, serviceImages(composeFile))
+		case hash != "":
+			err = printServiceHashes(out, composeFile, hash)
+		default:
+			err = printComposeFile(out, composeFile, format)
+		}
+
+		if err != nil {
+			logger.Error("Error printing compose file: %s\n", err)
+			return err
+		}
+
+		return nil
+	}
+
+	addComposeFlags(cmd, &compose)
+	cmd.Flags().StringVar(&format, "format", yamlFormat, "Output format: yaml or json")
+	cmd.Flags().BoolVar(&services, "services", false, "Print the service names, one per line")
+	cmd.Flags().BoolVar(&images, "images", false, "Print the image names, one per line")
+	cmd.Flags().StringVar(&hash, "hash", "", `Print the configuration hash of the comma separated services, "*" for all`)
+	cmd.MarkFlagsMutuallyExclusive("services", "images", "hash")
+
+	return cmd
+}
+
+func printComposeFile(out io.Writer, composeFile *yaml.ComposeFile, format string) error {
+	var document []byte
+	var err error
+	switch format {
+	case yamlFormat:
+		document, err = composeFile.YAML()
+	case jsonFormat:
+		document, err = composeFile.JSON()
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if format == jsonFormat {
+		document = append(document, '\n')
+	}
+
+	_, err = out.Write(document)
+	return err
+}
+
+// serviceImages returns the distinct images of the services ordered by service name,
+// including the names the images built for the services are tagged with.
+func serviceImages(composeFile *yaml.ComposeFile) []string {
+	seen := make(map[string]bool)
+	var images []string
+	for _, name := range sortedServiceNames(composeFile) {
+		image := composeFile.ImageName(name)
+		if seen[image] {
+			continue
+		}
+		seen[image] = true
+		images = append(images, image)
+	}
+
+	return images
+}
+
+// printServiceHashes prints the configuration hash the containers of the services are labelled with.
+func printServiceHashes(out io.Writer, composeFile *yaml.ComposeFile, hash string) error {
+	selection := serviceSelection{all: hash == allServicesHash}
+	if !selection.all {
+		selection.services = strings.Split(hash, ",")
+	}
+
+	names, err := selection.resolve(composeFile)
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		container := newDockerContainer(name, composeFile.Services[name], composeFile)
+		if _, err := fmt.Fprintf(out, "%s %s\n", name, container.ConfigHash()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func printLines(out io.Writer, lines []string) error {
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(out, line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package command_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/petrovskiborislav/docker-cli/command"
+	"github.com/petrovskiborislav/docker-cli/logger"
+)
+
+type configTestSuite struct {
+	suite.Suite
+	out *bytes.Buffer
+	sut *cobra.Command
+}
+
+func (s *configTestSuite) SetupTest() {
+	s.out = &bytes.Buffer{}
+	s.sut = command.NewConfigCommand(logger.NewLogger())
+	s.sut.SetOut(s.out)
+}
+
+func TestSuite_Config(t *testing.T) {
+	suite.Run(t, &configTestSuite{})
+}
+
+func (s *configTestSuite) TestConfig_WhenYAMLFormat_ThenResolvedModelIsPrinted() {
+	// Arrange
+	s.Require().NoError(s.sut.Flags().Set("file", filePath))
+	s.Require().NoError(s.sut.Flags().Set("file", "testdata/cache-override-compose.yaml"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{})
+
+	// Assert
+	s.NoError(err)
+	s.Contains(s.out.String(), "name: docker-cli\nservices:\n  cache:\n    image: memcached:alpine\n")
+	s.Contains(s.out.String(), "    depends_on:\n      db:\n        condition: service_healthy\n")
+}
+
+func (s *configTestSuite) TestConfig_WhenJSONFormat_ThenResolvedModelIsPrinted() {
+	// Arrange
+	s.Require().NoError(s.sut.Flags().Set("format", "json"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.NoError(err)
+	var model struct {
+		Name     string
+		Services map[string]struct {
+			Image       string
+			Environment map[string]string
+		}
+	}
+	s.Require().NoError(json.Unmarshal(s.out.Bytes(), &model))
+	s.Equal("docker-cli", model.Name)
+	s.Len(model.Services, 4)
+	s.Equal("mysql:latest", model.Services["db"].Image)
+	s.Equal(map[string]string{"MYSQL_ALLOW_EMPTY_PASSWORD": "true"}, model.Services["db"].Environment)
+}
+
+func (s *configTestSuite) TestConfig_WhenServicesFlagIsSet_ThenServiceNamesArePrinted() {
+	// Arrange
+	s.Require().NoError(s.sut.Flags().Set("services", "true"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.NoError(err)
+	s.Equal("cache\ndb\nnginx\nwordpress\n", s.out.String())
+}
+
+func (s *configTestSuite) TestConfig_WhenImagesFlagIsSet_ThenImagesArePrinted() {
+	// Arrange
+	s.Require().NoError(s.sut.Flags().Set("images", "true"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.NoError(err)
+	s.Equal("memcached\nmysql:latest\nnginx:alpine\nwordpress:6.0\n", s.out.String())
+}
+
+func (s *configTestSuite) TestConfig_WhenServiceIsBuilt_ThenDefaultImageNameIsPrinted() {
+	// Arrange
+	s.Require().NoError(s.sut.Flags().Set("images", "true"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{"testdata/build-compose.yaml"})
+
+	// Assert
+	s.NoError(err)
+	s.Equal("docker-cli-api\nredis:7\n", s.out.String())
+}
+
+func (s *configTestSuite) TestConfig_WhenHashFlagIsSet_ThenConfigHashesArePrinted() {
+	// Arrange
+	s.Require().NoError(s.sut.Flags().Set("hash", "nginx,cache"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.NoError(err)
+	want := "cache " + cacheContainer().ConfigHash() + "\n" +
+		"nginx " + nginxContainer().ConfigHash() + "\n"
+	s.Equal(want, s.out.String())
+}
+
+func (s *configTestSuite) TestConfig_WhenHashedServiceIsUndefined_ThenFailure() {
+	// Arrange
+	s.Require().NoError(s.sut.Flags().Set("hash", "missing"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.Error(err)
+	s.Empty(s.out.String())
+}
+
+func (s *configTestSuite) TestConfig_WhenFormatIsUnknown_ThenFailure() {
+	// Arrange
+	s.Require().NoError(s.sut.Flags().Set("format", "xml"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.Error(err)
+	s.Empty(s.out.String())
+}
+
+func (s *configTestSuite) TestConfig_WhenComposeFileEnvIsSet_ThenListedFilesAreMerged() {
+	// Arrange
+	s.T().Setenv("COMPOSE_PATH_SEPARATOR", ";")
+	s.T().Setenv("COMPOSE_FILE", filePath+";testdata/cache-override-compose.yaml")
+	s.Require().NoError(s.sut.Flags().Set("images", "true"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{})
+
+	// Assert
+	s.NoError(err)
+	s.Equal("memcached:alpine\nmysql:latest\nnginx:alpine\nwordpress:6.0\n", s.out.String())
+}
+
+func (s *configTestSuite) TestConfig_WhenNoComposeFileIsGiven_ThenFileIsDiscoveredInParents() {
+	// Arrange
+	root := s.T().TempDir()
+	nested := filepath.Join(root, "app", "src")
+	s.Require().NoError(os.MkdirAll(nested, 0o755))
+	s.Require().NoError(os.WriteFile(filepath.Join(root, "compose.yaml"), []byte("services:\n  api:\n    image: golang\n"), 0o600))
+	s.Require().NoError(os.WriteFile(filepath.Join(root, "compose.override.yaml"), []byte("services:\n  api:\n    image: golang:alpine\n"), 0o600))
+
+	workingDir, err := os.Getwd()
+	s.Require().NoError(err)
+	s.Require().NoError(os.Chdir(nested))
+	defer func() { s.Require().NoError(os.Chdir(workingDir)) }()
+
+	s.Require().NoError(s.sut.Flags().Set("images", "true"))
+
+	// Act
+	err = s.sut.RunE(nil, []string{})
+
+	// Assert
+	s.NoError(err)
+	s.Equal("golang:alpine\n", s.out.String())
+}
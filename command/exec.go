@@ -0,0 +1,125 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moby/term"
+	"github.com/spf13/cobra"
+
+	"github.com/petrovskiborislav/docker-cli/docker"
+	"github.com/petrovskiborislav/docker-cli/logger"
+)
+
+// ExitError is returned by commands which have to exit with a specific code.
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// NewExecCommand creates exec command which runs
+// a command inside the container of a service.
+func NewExecCommand(ctx context.Context, logger logger.Logger, client docker.Client) *cobra.Command {
+	compose := composeOptions{logger: logger}
+	var noTty bool
+	var options docker.ExecOptions
+
+	cmd := &cobra.Command{
+		Use:           "exec [flags] SERVICE [--] COMMAND [ARGS...]",
+		Short:         "Runs a command inside the running container of a service",
+		Args:          cobra.MinimumNArgs(2),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	cmd.RunE = func(_ *cobra.Command, args []string) error {
+		composeFile, err := compose.parseComposeFile(nil)
+		if err != nil {
+			logger.Error("Error parsing compose file: %s\n", err)
+			return err
+		}
+
+		selection := serviceSelection{services: args[:1]}
+		selectedServices, err := selection.resolve(composeFile)
+		if err != nil {
+			logger.Error("Error selecting services: %s\n", err)
+			return err
+		}
+
+		serviceContainers, err := selectedServicesToContainers(selectedServices, composeFile, false)
+		if err != nil {
+			logger.Error("Error selecting services: %s\n", err)
+			return err
+		}
+
+		// Parsing stops at the service name, so a "--" separating the command is still among the arguments.
+		options.Command = args[1:]
+		if options.Command[0] == "--" {
+			options.Command = options.Command[1:]
+		}
+		if len(options.Command) == 0 {
+			err = fmt.Errorf("no command given for service %s", args[0])
+			logger.Error("Error executing command: %s\n", err)
+			return err
+		}
+		streams := docker.ExecStreams{Out: cmd.OutOrStdout(), Err: cmd.ErrOrStderr()}
+		if options.Interactive {
+			streams.In = cmd.InOrStdin()
+		}
+
+		// A TTY is only allocated when the CLI itself runs in a terminal.
+		fd, isTerminal := term.GetFdInfo(cmd.InOrStdin())
+		options.Tty = !noTty && options.Interactive && isTerminal
+		if options.Tty {
+			state, err := term.SetRawTerminal(fd)
+			if err != nil {
+				logger.Error("Error setting up terminal: %s\n", err)
+				return err
+			}
+			defer term.RestoreTerminal(fd, state)
+
+			resizeCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			streams.Resize = monitorTerminalSize(resizeCtx, fd)
+		}
+
+		exitCode, err := client.ExecService(ctx, serviceContainers[0], options, streams)
+		if err != nil {
+			logger.Error("Error executing command: %s\n", err)
+			return err
+		}
+
+		if exitCode != 0 {
+			return &ExitError{Code: exitCode}
+		}
+
+		return nil
+	}
+
+	// Flags after the service name belong to the command run in the container, e.g. "exec db mysql -uroot".
+	cmd.Flags().SetInterspersed(false)
+	addComposeFlags(cmd, &compose)
+	cmd.Flags().BoolVarP(&noTty, "no-tty", "T", false, "Disable pseudo-TTY allocation")
+	cmd.Flags().BoolVarP(&options.Interactive, "interactive", "i", true, "Keep STDIN attached")
+	cmd.Flags().StringVarP(&options.User, "user", "u", "", "Run the command as this user")
+	cmd.Flags().StringVarP(&options.WorkingDir, "workdir", "w", "", "Path to the working directory of the command")
+	cmd.Flags().StringArrayVarP(&options.Env, "env", "e", nil, "Set environment variables (KEY=VALUE)")
+
+	return cmd
+}
+
+// sendTerminalSize sends the current size of the terminal unless the context is done.
+func sendTerminalSize(ctx context.Context, fd uintptr, resize chan<- docker.TerminalSize) {
+	winsize, err := term.GetWinsize(fd)
+	if err != nil || winsize.Height == 0 || winsize.Width == 0 {
+		return
+	}
+
+	select {
+	case resize <- docker.TerminalSize{Height: uint(winsize.Height), Width: uint(winsize.Width)}:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,182 @@
+package command_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/petrovskiborislav/docker-cli/command"
+	"github.com/petrovskiborislav/docker-cli/docker"
+	"github.com/petrovskiborislav/docker-cli/logger"
+)
+
+type execTestSuite struct {
+	suite.Suite
+	client *mockClient
+	in     *strings.Reader
+	out    *bytes.Buffer
+	sut    *cobra.Command
+}
+
+func (s *execTestSuite) SetupTest() {
+	s.client = &mockClient{}
+	s.in = strings.NewReader("SELECT 1;\n")
+	s.out = &bytes.Buffer{}
+	s.sut = command.NewExecCommand(context.Background(), logger.NewLogger(), s.client)
+	s.sut.SetIn(s.in)
+	s.sut.SetOut(s.out)
+	s.sut.SetErr(s.out)
+	s.T().Setenv("COMPOSE_FILE", filePath)
+}
+
+func TestSuite_Exec(t *testing.T) {
+	suite.Run(t, &execTestSuite{})
+}
+
+func (s *execTestSuite) TestExec_WhenCommandSucceeds_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+	options := docker.ExecOptions{Command: []string{"mysql", "-uroot"}, Interactive: true}
+	streams := docker.ExecStreams{In: s.in, Out: s.out, Err: s.out}
+
+	s.client.On("ExecService", ctx, dbContainer(), options, streams).Return(0, nil)
+
+	// Act
+	err := s.sut.RunE(nil, []string{"db", "mysql", "-uroot"})
+
+	// Assert
+	s.NoError(err)
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *execTestSuite) TestExec_WhenFlagsAreSet_ThenOptionsArePassed() {
+	// Arrange
+	ctx := context.Background()
+	options := docker.ExecOptions{
+		Command:    []string{"ls"},
+		User:       "www-data",
+		WorkingDir: "/var/www",
+		Env:        []string{"A=1", "B=2"},
+	}
+	streams := docker.ExecStreams{Out: s.out, Err: s.out}
+
+	s.client.On("ExecService", ctx, wordpressContainer(), options, streams).Return(0, nil)
+
+	s.Require().NoError(s.sut.Flags().Set("file", filePath))
+	s.Require().NoError(s.sut.Flags().Set("interactive", "false"))
+	s.Require().NoError(s.sut.Flags().Set("user", "www-data"))
+	s.Require().NoError(s.sut.Flags().Set("workdir", "/var/www"))
+	s.Require().NoError(s.sut.Flags().Set("env", "A=1"))
+	s.Require().NoError(s.sut.Flags().Set("env", "B=2"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{"wordpress", "ls"})
+
+	// Assert
+	s.NoError(err)
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *execTestSuite) TestExec_WhenCommandHasFlags_ThenTheyAreNotParsed() {
+	// Arrange
+	ctx := context.Background()
+	options := docker.ExecOptions{Command: []string{"mysql", "-uroot", "-e", "SELECT 1"}, User: "mysql", Interactive: true}
+	streams := docker.ExecStreams{In: s.in, Out: s.out, Err: s.out}
+
+	s.client.On("ExecService", ctx, dbContainer(), options, streams).Return(0, nil)
+	s.Require().NoError(s.sut.ParseFlags([]string{"-u", "mysql", "db", "mysql", "-uroot", "-e", "SELECT 1"}))
+
+	// Act
+	err := s.sut.RunE(nil, s.sut.Flags().Args())
+
+	// Assert
+	s.NoError(err)
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *execTestSuite) TestExec_WhenCommandIsSeparatedByDashes_ThenDashesAreDropped() {
+	// Arrange
+	ctx := context.Background()
+	options := docker.ExecOptions{Command: []string{"mysql", "-uroot"}, User: "mysql", Interactive: true}
+	streams := docker.ExecStreams{In: s.in, Out: s.out, Err: s.out}
+
+	s.client.On("ExecService", ctx, dbContainer(), options, streams).Return(0, nil)
+	s.Require().NoError(s.sut.ParseFlags([]string{"-u", "mysql", "db", "--", "mysql", "-uroot"}))
+
+	// Act
+	err := s.sut.RunE(nil, s.sut.Flags().Args())
+
+	// Assert
+	s.NoError(err)
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *execTestSuite) TestExec_WhenOnlyDashesFollowTheService_ThenFailure() {
+	// Arrange
+
+	// Act
+	err := s.sut.RunE(nil, []string{"db", "--"})
+
+	// Assert
+	s.Error(err)
+	s.client.AssertNotCalled(s.T(), "ExecService")
+}
+
+func (s *execTestSuite) TestExec_WhenCommandFails_ThenExitCodeIsReturned() {
+	// Arrange
+	ctx := context.Background()
+
+	s.client.On("ExecService", ctx, dbContainer(), mock.Anything, mock.Anything).Return(3, nil)
+
+	// Act
+	err := s.sut.RunE(nil, []string{"db", "false"})
+
+	// Assert
+	var exitErr *command.ExitError
+	s.Require().ErrorAs(err, &exitErr)
+	s.Equal(3, exitErr.Code)
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *execTestSuite) TestExec_WhenServiceIsNotDefined_ThenFailure() {
+	// Arrange
+
+	// Act
+	err := s.sut.RunE(nil, []string{"unknown", "ls"})
+
+	// Assert
+	s.EqualError(err, "service unknown is not defined")
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *execTestSuite) TestExec_WhenErrorOccursOnParsing_ThenFailure() {
+	// Arrange
+	s.Require().NoError(s.sut.Flags().Set("file", "missing-compose.yaml"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{"db", "ls"})
+
+	// Assert
+	s.Error(err)
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *execTestSuite) TestExec_WhenErrorOccursOnExecuting_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+
+	s.client.On("ExecService", ctx, dbContainer(), mock.Anything, mock.Anything).Return(0, errors.New("error"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{"db", "ls"})
+
+	// Assert
+	s.EqualError(err, "error")
+	s.client.AssertExpectations(s.T())
+}
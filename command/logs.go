@@ -0,0 +1,58 @@
+package command
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/petrovskiborislav/docker-cli/docker"
+	"github.com/petrovskiborislav/docker-cli/logger"
+	"github.com/petrovskiborislav/docker-cli/prompt"
+)
+
+const defaultLogsTail = "all"
+
+// NewLogsCommand creates logs command which reads compose
+// file and prints the logs of the selected services.
+func NewLogsCommand(ctx context.Context, logger logger.Logger, prompt prompt.Prompt, client docker.Client) *cobra.Command {
+	compose := composeOptions{logger: logger}
+	var selection serviceSelection
+	var options docker.LogOptions
+
+	cmd := &cobra.Command{
+		Use:           "logs [PATH to docker-compose file]",
+		Short:         "Prints the logs of the selected services listed from the specified compose file",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	cmd.RunE = func(_ *cobra.Command, args []string) error {
+		composeFile, err := compose.parseComposeFile(args)
+		if err != nil {
+			logger.Error("Error parsing compose file: %s\n", err)
+			return err
+		}
+
+		selectedServiceContainers, err := selectServiceContainers("Select services to print logs of", prompt, composeFile, selection, false)
+		if err != nil {
+			logger.Error("Error selecting services: %s\n", err)
+			return err
+		}
+
+		if err := client.StreamLogs(ctx, selectedServiceContainers, options, cmd.OutOrStdout()); err != nil {
+			logger.Error("Error printing logs: %s\n", err)
+			return err
+		}
+
+		return nil
+	}
+
+	addComposeFlags(cmd, &compose)
+	addServiceSelectionFlags(cmd, &selection)
+	cmd.Flags().BoolVar(&options.Follow, "follow", false, "Follow the log output until interrupted")
+	cmd.Flags().StringVar(&options.Tail, "tail", defaultLogsTail, "Number of lines to show from the end of the logs")
+	cmd.Flags().StringVar(&options.Since, "since", "", "Show logs since a timestamp (e.g. 2022-10-01T15:04:05) or relative duration (e.g. 30m)")
+	cmd.Flags().BoolVarP(&options.Timestamps, "timestamps", "t", false, "Show timestamps")
+
+	return cmd
+}
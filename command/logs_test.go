@@ -0,0 +1,137 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/petrovskiborislav/docker-cli/command"
+	"github.com/petrovskiborislav/docker-cli/docker"
+	"github.com/petrovskiborislav/docker-cli/logger"
+)
+
+type logsTestSuite struct {
+	suite.Suite
+	client *mockClient
+	prompt *mockPrompt
+	sut    *cobra.Command
+}
+
+func (s *logsTestSuite) SetupTest() {
+	s.client = &mockClient{}
+	s.prompt = &mockPrompt{}
+	s.sut = command.NewLogsCommand(context.Background(), logger.NewLogger(), s.prompt, s.client)
+}
+
+func TestSuite_Logs(t *testing.T) {
+	suite.Run(t, &logsTestSuite{})
+}
+
+func (s *logsTestSuite) TestLogs_WhenServicesSelected_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+
+	msg := "Select services to print logs of"
+	items := []string{"all", "nginx", "db", "cache", "wordpress"}
+	containers := []docker.Container{wordpressContainer()}
+	options := docker.LogOptions{Tail: "all"}
+
+	matcher := mock.MatchedBy(matchElements(items))
+	s.prompt.On("SelectPrompt", msg, matcher).Return(items[4:5], nil)
+	s.client.On("StreamLogs", ctx, containers, options, mock.Anything).Return(nil)
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.NoError(err)
+	s.prompt.AssertExpectations(s.T())
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *logsTestSuite) TestLogs_WhenFlagsAreSet_ThenPromptIsSkipped() {
+	// Arrange
+	ctx := context.Background()
+
+	containers := []docker.Container{dbContainer(), cacheContainer()}
+	options := docker.LogOptions{Follow: true, Tail: "20", Since: "10m", Timestamps: true}
+
+	s.client.On("StreamLogs", ctx, containers, options, mock.Anything).Return(nil)
+
+	s.Require().NoError(s.sut.Flags().Set("service", "db"))
+	s.Require().NoError(s.sut.Flags().Set("service", "cache"))
+	s.Require().NoError(s.sut.Flags().Set("follow", "true"))
+	s.Require().NoError(s.sut.Flags().Set("tail", "20"))
+	s.Require().NoError(s.sut.Flags().Set("since", "10m"))
+	s.Require().NoError(s.sut.Flags().Set("timestamps", "true"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.NoError(err)
+	s.prompt.AssertExpectations(s.T())
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *logsTestSuite) TestLogs_WhenErrorOccursOnParsing_ThenFailure() {
+	// Arrange
+
+	// Act
+	err := s.sut.RunE(nil, []string{""})
+
+	// Assert
+	s.Error(err)
+	s.prompt.AssertExpectations(s.T())
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *logsTestSuite) TestLogs_WhenErrorOccursOnSelectingServices_ThenFailure() {
+	// Arrange
+	msg := "Select services to print logs of"
+	items := []string{"all", "nginx", "db", "cache", "wordpress"}
+
+	matcher := mock.MatchedBy(matchElements(items))
+	s.prompt.On("SelectPrompt", msg, matcher).Return(nil, errors.New("error"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.Error(err)
+	s.prompt.AssertExpectations(s.T())
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *logsTestSuite) TestLogs_WhenErrorOccursOnStreamingLogs_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+
+	s.client.On("StreamLogs", ctx, []docker.Container{nginxContainer()}, docker.LogOptions{Tail: "all"}, mock.Anything).
+		Return(errors.New("error"))
+
+	s.Require().NoError(s.sut.Flags().Set("service", "nginx"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.Error(err)
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *logsTestSuite) TestLogs_WhenSelectedServiceIsUndefined_ThenFailure() {
+	// Arrange
+	s.Require().NoError(s.sut.Flags().Set("service", "unknown"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.Error(err)
+	s.client.AssertNotCalled(s.T(), "StreamLogs")
+}
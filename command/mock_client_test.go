@@ -4,6 +4,8 @@ package command_test
 
 import (
 	context "context"
+	io "io"
+	time "time"
 
 	docker "github.com/petrovskiborislav/docker-cli/docker"
 	mock "github.com/stretchr/testify/mock"
@@ -14,6 +16,80 @@ type mockClient struct {
 	mock.Mock
 }
 
+// ExecService provides a mock function with given fields: ctx, container, options, streams
+func (_m *mockClient) ExecService(ctx context.Context, container docker.Container, options docker.ExecOptions, streams docker.ExecStreams) (int, error) {
+	ret := _m.Called(ctx, container, options, streams)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, docker.Container, docker.ExecOptions, docker.ExecStreams) int); ok {
+		r0 = rf(ctx, container, options, streams)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, docker.Container, docker.ExecOptions, docker.ExecStreams) error); ok {
+		r1 = rf(ctx, container, options, streams)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListServices provides a mock function with given fields: ctx, containers, all
+func (_m *mockClient) ListServices(ctx context.Context, containers []docker.Container, all bool) ([]docker.ServiceStatus, error) {
+	ret := _m.Called(ctx, containers, all)
+
+	var r0 []docker.ServiceStatus
+	if rf, ok := ret.Get(0).(func(context.Context, []docker.Container, bool) []docker.ServiceStatus); ok {
+		r0 = rf(ctx, containers, all)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]docker.ServiceStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []docker.Container, bool) error); ok {
+		r1 = rf(ctx, containers, all)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PullImages provides a mock function with given fields: ctx, containers, parallelism
+func (_m *mockClient) PullImages(ctx context.Context, containers []docker.Container, parallelism int) []docker.PullResult {
+	ret := _m.Called(ctx, containers, parallelism)
+
+	var r0 []docker.PullResult
+	if rf, ok := ret.Get(0).(func(context.Context, []docker.Container, int) []docker.PullResult); ok {
+		r0 = rf(ctx, containers, parallelism)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]docker.PullResult)
+		}
+	}
+
+	return r0
+}
+
+// RestartService provides a mock function with given fields: ctx, container, timeout
+func (_m *mockClient) RestartService(ctx context.Context, container docker.Container, timeout time.Duration) error {
+	ret := _m.Called(ctx, container, timeout)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, docker.Container, time.Duration) error); ok {
+		r0 = rf(ctx, container, timeout)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ServiceDecommissioning provides a mock function with given fields: ctx, container
 func (_m *mockClient) ServiceDecommissioning(ctx context.Context, container docker.Container) error {
 	ret := _m.Called(ctx, container)
@@ -28,13 +104,41 @@ func (_m *mockClient) ServiceDecommissioning(ctx context.Context, container dock
 	return r0
 }
 
-// ServiceProvisioning provides a mock function with given fields: ctx, container
-func (_m *mockClient) ServiceProvisioning(ctx context.Context, container docker.Container) error {
-	ret := _m.Called(ctx, container)
+// ServiceProvisioning provides a mock function with given fields: ctx, container, options
+func (_m *mockClient) ServiceProvisioning(ctx context.Context, container docker.Container, options docker.ProvisioningOptions) error {
+	ret := _m.Called(ctx, container, options)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, docker.Container) error); ok {
-		r0 = rf(ctx, container)
+	if rf, ok := ret.Get(0).(func(context.Context, docker.Container, docker.ProvisioningOptions) error); ok {
+		r0 = rf(ctx, container, options)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StreamLogs provides a mock function with given fields: ctx, containers, options, out
+func (_m *mockClient) StreamLogs(ctx context.Context, containers []docker.Container, options docker.LogOptions, out io.Writer) error {
+	ret := _m.Called(ctx, containers, options, out)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []docker.Container, docker.LogOptions, io.Writer) error); ok {
+		r0 = rf(ctx, containers, options, out)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WaitForServices provides a mock function with given fields: ctx, containerNames, condition, timeout
+func (_m *mockClient) WaitForServices(ctx context.Context, containerNames []string, condition string, timeout time.Duration) error {
+	ret := _m.Called(ctx, containerNames, condition, timeout)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, string, time.Duration) error); ok {
+		r0 = rf(ctx, containerNames, condition, timeout)
 	} else {
 		r0 = ret.Error(0)
 	}
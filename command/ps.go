@@ -0,0 +1,135 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/petrovskiborislav/docker-cli/docker"
+	"github.com/petrovskiborislav/docker-cli/logger"
+)
+
+const (
+	tableFormat      = "table"
+	jsonFormat       = "json"
+	shortIDLength    = 12
+	missingTableCell = "-"
+)
+
+// NewPsCommand creates ps command which reads compose
+// file and lists the containers of its services.
+func NewPsCommand(ctx context.Context, logger logger.Logger, client docker.Client) *cobra.Command {
+	compose := composeOptions{logger: logger}
+	var all bool
+	var format string
+
+	cmd := &cobra.Command{
+		Use:           "ps [PATH to docker-compose file]",
+		Short:         "Lists the containers of the services listed in the specified compose file",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	cmd.RunE = func(_ *cobra.Command, args []string) error {
+		composeFile, err := compose.parseComposeFile(args)
+		if err != nil {
+			logger.Error("Error parsing compose file: %s\n", err)
+			return err
+		}
+
+		serviceContainers, err := selectedServicesToContainers(sortedServiceNames(composeFile), composeFile, false)
+		if err != nil {
+			logger.Error("Error selecting services: %s\n", err)
+			return err
+		}
+
+		statuses, err := client.ListServices(ctx, serviceContainers, all)
+		if err != nil {
+			logger.Error("Error listing services: %s\n", err)
+			return err
+		}
+
+		if err := printServiceStatuses(cmd.OutOrStdout(), format, statuses); err != nil {
+			logger.Error("Error printing services: %s\n", err)
+			return err
+		}
+
+		return nil
+	}
+
+	addComposeFlags(cmd, &compose)
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "Include stopped containers")
+	cmd.Flags().StringVar(&format, "format", tableFormat, "Output format: table, json or a Go template")
+
+	return cmd
+}
+
+func printServiceStatuses(out io.Writer, format string, statuses []docker.ServiceStatus) error {
+	switch format {
+	case tableFormat:
+		return printServiceStatusesTable(out, statuses)
+	case jsonFormat:
+		if statuses == nil {
+			statuses = []docker.ServiceStatus{}
+		}
+
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(statuses)
+	default:
+		tmpl, err := template.New("format").Funcs(template.FuncMap{"join": strings.Join}).Parse(format)
+		if err != nil {
+			return err
+		}
+		// Fields the statuses do not have are reported even when no container is listed.
+		if err = tmpl.Execute(io.Discard, docker.ServiceStatus{}); err != nil {
+			return err
+		}
+
+		for _, status := range statuses {
+			if err := tmpl.Execute(out, status); err != nil {
+				return err
+			}
+			fmt.Fprintln(out)
+		}
+
+		return nil
+	}
+}
+
+func printServiceStatusesTable(out io.Writer, statuses []docker.ServiceStatus) error {
+	writer := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(writer, "SERVICE\tCONTAINER ID\tIMAGE\tSTATE\tHEALTH\tUPTIME\tPORTS")
+	for _, status := range statuses {
+		containerID := status.ContainerID
+		if len(containerID) > shortIDLength {
+			containerID = containerID[:shortIDLength]
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			status.Service,
+			containerID,
+			status.Image,
+			status.State,
+			tableCell(status.Health),
+			tableCell(status.Uptime),
+			tableCell(strings.Join(status.Ports, ", ")),
+		)
+	}
+
+	return writer.Flush()
+}
+
+func tableCell(value string) string {
+	if value == "" {
+		return missingTableCell
+	}
+
+	return value
+}
@@ -0,0 +1,169 @@
+package command_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/petrovskiborislav/docker-cli/command"
+	"github.com/petrovskiborislav/docker-cli/docker"
+	"github.com/petrovskiborislav/docker-cli/logger"
+)
+
+type psTestSuite struct {
+	suite.Suite
+	client *mockClient
+	out    *bytes.Buffer
+	sut    *cobra.Command
+}
+
+func (s *psTestSuite) SetupTest() {
+	s.client = &mockClient{}
+	s.out = &bytes.Buffer{}
+	s.sut = command.NewPsCommand(context.Background(), logger.NewLogger(), s.client)
+	s.sut.SetOut(s.out)
+}
+
+func TestSuite_Ps(t *testing.T) {
+	suite.Run(t, &psTestSuite{})
+}
+
+func (s *psTestSuite) TestPs_WhenTableFormat_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+	statuses := []docker.ServiceStatus{
+		{
+			Service:     "db",
+			ContainerID: "0123456789abcdef",
+			Image:       "mysql:latest",
+			State:       "running",
+			Health:      "healthy",
+			Uptime:      "3 hours",
+			Ports:       []string{"0.0.0.0:3306->3306/tcp"},
+		},
+		{Service: "cache", ContainerID: "fedcba9876543210", Image: "memcached", State: "exited"},
+	}
+
+	s.client.On("ListServices", ctx, mock.Anything, true).Return(statuses, nil)
+	s.Require().NoError(s.sut.Flags().Set("all", "true"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.NoError(err)
+	want := "SERVICE   CONTAINER ID   IMAGE          STATE     HEALTH    UPTIME    PORTS\n" +
+		"db        0123456789ab   mysql:latest   running   healthy   3 hours   0.0.0.0:3306->3306/tcp\n" +
+		"cache     fedcba987654   memcached      exited    -         -         -\n"
+
+	s.client.AssertExpectations(s.T())
+	s.Equal(want, s.out.String())
+}
+
+func (s *psTestSuite) TestPs_WhenJSONFormat_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+	statuses := []docker.ServiceStatus{{Service: "cache", ContainerID: "id", Image: "memcached", State: "running"}}
+
+	s.client.On("ListServices", ctx, mock.Anything, false).Return(statuses, nil)
+	s.Require().NoError(s.sut.Flags().Set("format", "json"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.NoError(err)
+	want := `[
+  {
+    "service": "cache",
+    "containerId": "id",
+    "image": "memcached",
+    "state": "running"
+  }
+]
+`
+
+	s.client.AssertExpectations(s.T())
+	s.Equal(want, s.out.String())
+}
+
+func (s *psTestSuite) TestPs_WhenTemplateFormat_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+	statuses := []docker.ServiceStatus{
+		{Service: "db", State: "running"},
+		{Service: "cache", State: "exited"},
+	}
+
+	s.client.On("ListServices", ctx, mock.Anything, false).Return(statuses, nil)
+	s.Require().NoError(s.sut.Flags().Set("format", "{{.Service}}={{.State}}"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.NoError(err)
+	s.client.AssertExpectations(s.T())
+	s.Equal("db=running\ncache=exited\n", s.out.String())
+}
+
+func (s *psTestSuite) TestPs_WhenTemplateHasUnknownField_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+
+	s.client.On("ListServices", ctx, mock.Anything, false).Return(nil, nil)
+	s.Require().NoError(s.sut.Flags().Set("format", "{{.Nope}}"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.Error(err)
+	s.Empty(s.out.String())
+}
+
+func (s *psTestSuite) TestPs_WhenAllServicesAreListed_ThenContainersAreLookedUp() {
+	// Arrange
+	ctx := context.Background()
+	containers := []docker.Container{cacheContainer(), dbContainer(), nginxContainer(), wordpressContainer()}
+
+	s.client.On("ListServices", ctx, containers, false).Return(nil, nil)
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.NoError(err)
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *psTestSuite) TestPs_WhenErrorOccursOnListingServices_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+
+	s.client.On("ListServices", ctx, mock.Anything, false).Return(nil, errors.New("error"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.Error(err)
+	s.client.AssertExpectations(s.T())
+	s.Empty(s.out.String())
+}
+
+func (s *psTestSuite) TestPs_WhenErrorOccursOnParsing_ThenFailure() {
+	// Arrange
+
+	// Act
+	err := s.sut.RunE(nil, []string{""})
+
+	// Assert
+	s.Error(err)
+	s.client.AssertNotCalled(s.T(), "ListServices")
+}
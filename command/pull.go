@@ -0,0 +1,96 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/petrovskiborislav/docker-cli/docker"
+	"github.com/petrovskiborislav/docker-cli/logger"
+	"github.com/petrovskiborislav/docker-cli/prompt"
+)
+
+const defaultPullParallelism = 4
+
+// NewPullCommand creates pull command which pulls the images of the selected
+// services concurrently and reports the outcome of every image.
+func NewPullCommand(ctx context.Context, logger logger.Logger, prompt prompt.Prompt, client docker.Client) *cobra.Command {
+	compose := composeOptions{logger: logger}
+	var selection serviceSelection
+	var parallelism int
+
+	cmd := &cobra.Command{
+		Use:           "pull [PATH to docker-compose file]",
+		Short:         "Pulls the images of the selected services listed from the specified compose file",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	cmd.RunE = func(_ *cobra.Command, args []string) error {
+		if parallelism < 1 {
+			err := fmt.Errorf("invalid parallelism %d: it must be at least 1", parallelism)
+			logger.Error("Error pulling images: %s\n", err)
+			return err
+		}
+
+		composeFile, err := compose.parseComposeFile(args)
+		if err != nil {
+			logger.Error("Error parsing compose file: %s\n", err)
+			return err
+		}
+
+		selectedServiceContainers, err := selectServiceContainers("Select services to pull", prompt, composeFile, selection, false)
+		if err != nil {
+			logger.Error("Error selecting services: %s\n", err)
+			return err
+		}
+
+		results := client.PullImages(ctx, selectedServiceContainers, parallelism)
+		if err = printPullResults(cmd.OutOrStdout(), results); err != nil {
+			logger.Error("Error printing pull results: %s\n", err)
+			return err
+		}
+
+		failed := 0
+		for _, result := range results {
+			if result.Err != nil {
+				failed++
+			}
+		}
+		if failed > 0 {
+			err = fmt.Errorf("%d of %d images failed to pull", failed, len(results))
+			logger.Error("Error pulling images: %s\n", err)
+			return err
+		}
+
+		return nil
+	}
+
+	addComposeFlags(cmd, &compose)
+	addServiceSelectionFlags(cmd, &selection)
+	cmd.Flags().IntVar(&parallelism, "parallel", defaultPullParallelism, "Maximum number of images pulled at the same time")
+
+	return cmd
+}
+
+func printPullResults(out io.Writer, results []docker.PullResult) error {
+	writer := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(writer, "IMAGE\tSERVICES\tRESULT")
+	for _, result := range results {
+		outcome := "pulled"
+		switch {
+		case result.Skipped != "":
+			outcome = "skipped: " + result.Skipped
+		case result.Err != nil:
+			outcome = "failed: " + result.Err.Error()
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", result.Image, strings.Join(result.Services, ", "), outcome)
+	}
+
+	return writer.Flush()
+}
@@ -0,0 +1,92 @@
+package command_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/petrovskiborislav/docker-cli/command"
+	"github.com/petrovskiborislav/docker-cli/docker"
+	"github.com/petrovskiborislav/docker-cli/logger"
+)
+
+type pullTestSuite struct {
+	suite.Suite
+	client *mockClient
+	prompt *mockPrompt
+	out    *bytes.Buffer
+	sut    *cobra.Command
+}
+
+func (s *pullTestSuite) SetupTest() {
+	s.client = &mockClient{}
+	s.prompt = &mockPrompt{}
+	s.out = &bytes.Buffer{}
+	s.sut = command.NewPullCommand(context.Background(), logger.NewLogger(), s.prompt, s.client)
+	s.sut.SetOut(s.out)
+}
+
+func TestSuite_Pull(t *testing.T) {
+	suite.Run(t, &pullTestSuite{})
+}
+
+func (s *pullTestSuite) TestPull_WhenServicesAreSelected_ThenSummaryIsPrinted() {
+	// Arrange
+	ctx := context.Background()
+	results := []docker.PullResult{
+		{Image: "memcached", Services: []string{"cache"}},
+		{Image: "mysql:latest", Services: []string{"db"}},
+	}
+
+	s.client.On("PullImages", ctx, []docker.Container{dbContainer(), cacheContainer()}, 2).Return(results)
+	s.Require().NoError(s.sut.Flags().Set("service", "db"))
+	s.Require().NoError(s.sut.Flags().Set("service", "cache"))
+	s.Require().NoError(s.sut.Flags().Set("parallel", "2"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.NoError(err)
+	s.Equal("IMAGE          SERVICES   RESULT\n"+
+		"memcached      cache      pulled\n"+
+		"mysql:latest   db         pulled\n", s.out.String())
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *pullTestSuite) TestPull_WhenAnImageFailsToPull_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	results := []docker.PullResult{
+		{Image: "docker-cli-api", Services: []string{"api"}, Skipped: "built from a Dockerfile"},
+		{Image: "redis:7", Services: []string{"cache"}, Err: errors.New("manifest unknown")},
+	}
+
+	s.client.On("PullImages", ctx, []docker.Container{buildContainer(), buildCacheContainer()}, 4).Return(results)
+	s.Require().NoError(s.sut.Flags().Set("all", "true"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{"testdata/build-compose.yaml"})
+
+	// Assert
+	s.Require().Error(err)
+	s.Equal("1 of 2 images failed to pull", err.Error())
+	s.Contains(s.out.String(), "docker-cli-api   api        skipped: built from a Dockerfile\n")
+	s.Contains(s.out.String(), "redis:7          cache      failed: manifest unknown\n")
+}
+
+func (s *pullTestSuite) TestPull_WhenParallelismIsInvalid_ThenFailure() {
+	// Arrange
+	s.Require().NoError(s.sut.Flags().Set("parallel", "0"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.Error(err)
+	s.client.AssertNotCalled(s.T(), "PullImages")
+}
@@ -0,0 +1,36 @@
+//go:build !windows
+
+package command
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/petrovskiborislav/docker-cli/docker"
+)
+
+// monitorTerminalSize sends the size of the terminal and then its new size on every SIGWINCH
+// until the context is done.
+func monitorTerminalSize(ctx context.Context, fd uintptr) <-chan docker.TerminalSize {
+	resize := make(chan docker.TerminalSize, 1)
+	sigwinch := make(chan os.Signal, 1)
+	signal.Notify(sigwinch, syscall.SIGWINCH)
+
+	go func() {
+		defer signal.Stop(sigwinch)
+
+		sendTerminalSize(ctx, fd, resize)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sigwinch:
+				sendTerminalSize(ctx, fd, resize)
+			}
+		}
+	}()
+
+	return resize
+}
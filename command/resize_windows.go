@@ -0,0 +1,40 @@
+//go:build windows
+
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/moby/term"
+
+	"github.com/petrovskiborislav/docker-cli/docker"
+)
+
+// resizePollInterval is how often the terminal size is checked since Windows has no SIGWINCH.
+const resizePollInterval = 250 * time.Millisecond
+
+// monitorTerminalSize sends the size of the terminal and then its new size whenever it
+// changes until the context is done.
+func monitorTerminalSize(ctx context.Context, fd uintptr) <-chan docker.TerminalSize {
+	resize := make(chan docker.TerminalSize, 1)
+
+	go func() {
+		var previous term.Winsize
+		for {
+			winsize, err := term.GetWinsize(fd)
+			if err == nil && *winsize != previous {
+				previous = *winsize
+				sendTerminalSize(ctx, fd, resize)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(resizePollInterval):
+			}
+		}
+	}()
+
+	return resize
+}
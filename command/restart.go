@@ -0,0 +1,58 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/petrovskiborislav/docker-cli/docker"
+	"github.com/petrovskiborislav/docker-cli/logger"
+	"github.com/petrovskiborislav/docker-cli/prompt"
+)
+
+const defaultStopTimeout = 10 * time.Second
+
+// NewRestartCommand creates restart command which reads
+// compose file and restarts the selected services.
+func NewRestartCommand(ctx context.Context, logger logger.Logger, prompt prompt.Prompt, client docker.Client) *cobra.Command {
+	compose := composeOptions{logger: logger}
+	var selection serviceSelection
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:           "restart [PATH to docker-compose file]",
+		Short:         "Restarts the selected services listed from the specified compose file",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	cmd.RunE = func(_ *cobra.Command, args []string) error {
+		composeFile, err := compose.parseComposeFile(args)
+		if err != nil {
+			logger.Error("Error parsing compose file: %s\n", err)
+			return err
+		}
+
+		selectedServiceContainers, err := selectServiceContainers("Select services to restart", prompt, composeFile, selection, false)
+		if err != nil {
+			logger.Error("Error selecting services: %s\n", err)
+			return err
+		}
+
+		for _, serviceContainer := range selectedServiceContainers {
+			if err := client.RestartService(ctx, serviceContainer, timeout); err != nil {
+				logger.Error("Error restarting services: %s\n", err)
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	addComposeFlags(cmd, &compose)
+	addServiceSelectionFlags(cmd, &selection)
+	cmd.Flags().DurationVarP(&timeout, "timeout", "t", defaultStopTimeout, "Time to wait for a container to stop before killing it")
+
+	return cmd
+}
@@ -0,0 +1,113 @@
+package command_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/petrovskiborislav/docker-cli/command"
+	"github.com/petrovskiborislav/docker-cli/logger"
+)
+
+type restartTestSuite struct {
+	suite.Suite
+	client *mockClient
+	prompt *mockPrompt
+	sut    *cobra.Command
+}
+
+func (s *restartTestSuite) SetupTest() {
+	s.client = &mockClient{}
+	s.prompt = &mockPrompt{}
+	s.sut = command.NewRestartCommand(context.Background(), logger.NewLogger(), s.prompt, s.client)
+}
+
+func TestSuite_Restart(t *testing.T) {
+	suite.Run(t, &restartTestSuite{})
+}
+
+func (s *restartTestSuite) TestRestart_WhenServiceSelected_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+
+	msg := "Select services to restart"
+	items := []string{"all", "nginx", "db", "cache", "wordpress"}
+
+	matcher := mock.MatchedBy(matchElements(items))
+	s.prompt.On("SelectPrompt", msg, matcher).Return(items[2:3], nil)
+	s.client.On("RestartService", ctx, dbContainer(), 10*time.Second).Return(nil)
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.NoError(err)
+	s.prompt.AssertExpectations(s.T())
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *restartTestSuite) TestRestart_WhenFlagsAreSet_ThenServicesAreRestartedInOrder() {
+	// Arrange
+	ctx := context.Background()
+
+	s.client.On("RestartService", ctx, dbContainer(), 30*time.Second).Return(nil).Once()
+	s.client.On("RestartService", ctx, wordpressContainer(), 30*time.Second).Return(nil).Once()
+
+	s.Require().NoError(s.sut.Flags().Set("service", "wordpress"))
+	s.Require().NoError(s.sut.Flags().Set("service", "db"))
+	s.Require().NoError(s.sut.Flags().Set("timeout", "30s"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.NoError(err)
+	s.prompt.AssertExpectations(s.T())
+	s.client.AssertExpectations(s.T())
+	s.Equal(dbContainer(), s.client.Calls[0].Arguments.Get(1))
+}
+
+func (s *restartTestSuite) TestRestart_WhenErrorOccursOnParsing_ThenFailure() {
+	// Arrange
+
+	// Act
+	err := s.sut.RunE(nil, []string{""})
+
+	// Assert
+	s.Error(err)
+	s.prompt.AssertExpectations(s.T())
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *restartTestSuite) TestRestart_WhenErrorOccursOnRestarting_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+
+	s.client.On("RestartService", ctx, cacheContainer(), 10*time.Second).Return(errors.New("error")).Once()
+
+	s.Require().NoError(s.sut.Flags().Set("all", "true"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.Error(err)
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *restartTestSuite) TestRestart_WhenSelectedServiceIsUndefined_ThenFailure() {
+	// Arrange
+	s.Require().NoError(s.sut.Flags().Set("service", "unknown"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.Error(err)
+	s.client.AssertNotCalled(s.T(), "RestartService")
+}
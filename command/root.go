@@ -1,16 +1,28 @@
 package command
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
 	"github.com/spf13/cobra"
 
 	"github.com/petrovskiborislav/docker-cli/docker"
+	"github.com/petrovskiborislav/docker-cli/logger"
 	"github.com/petrovskiborislav/docker-cli/prompt"
 	"github.com/petrovskiborislav/docker-cli/yaml"
 )
 
 const (
-	allPromptOption        = "all"
-	defaultComposeFilePath = "../default-compose.yaml"
+	allPromptOption         = "all"
+	projectNameEnv          = "COMPOSE_PROJECT_NAME"
+	composeFileEnv          = "COMPOSE_FILE"
+	composePathSeparatorEnv = "COMPOSE_PATH_SEPARATOR"
 )
 
 // NewRootCommand creates the base command when called without any subcommands.
@@ -21,52 +33,513 @@ func NewRootCommand() *cobra.Command {
 	}
 }
 
-func parseComposeFile(args []string) (map[string]yaml.Service, error) {
-	filePath := defaultComposeFilePath
+// composeOptions holds the flags which apply to the compose file as a whole.
+type composeOptions struct {
+	logger      logger.Logger
+	files       []string
+	projectName string
+}
+
+func addComposeFlags(cmd *cobra.Command, options *composeOptions) {
+	cmd.Flags().StringArrayVarP(&options.files, "file", "f", nil,
+		"Compose file, can be repeated to merge several files (disables the automatic override file)")
+	cmd.Flags().StringVarP(&options.projectName, "project-name", "p", "",
+		"Project name prefixing the containers, networks and volumes (defaults to $"+projectNameEnv+" or the compose file directory)")
+}
+
+// composeFilePaths returns the files passed with --file, the file given as argument, the files listed
+// in $COMPOSE_FILE or the file found in the current directory or its parents, in that order. The
+// override file next to the argument or the found file is merged as well. Files which were not given
+// explicitly are reported.
+func (o composeOptions) composeFilePaths(args []string) ([]string, error) {
+	if len(o.files) > 0 {
+		return o.files, nil
+	}
+
 	if len(args) > 0 {
-		filePath = args[0]
+		return withOverrideFile(args[0]), nil
+	}
+
+	if env := os.Getenv(composeFileEnv); env != "" {
+		paths := splitComposeFileEnv(env)
+		o.logger.Info("Using compose file %s from $%s\n", strings.Join(paths, ", "), composeFileEnv)
+		return paths, nil
+	}
+
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	filePath, err := yaml.FindComposeFile(workingDir)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := withOverrideFile(filePath)
+	o.logger.Info("Using compose file %s\n", strings.Join(paths, ", "))
+	return paths, nil
+}
+
+// withOverrideFile appends the override file found next to the compose file, if any.
+func withOverrideFile(filePath string) []string {
+	if overridePath, ok := yaml.FindOverrideFile(filepath.Dir(filePath)); ok {
+		return []string{filePath, overridePath}
 	}
 
-	return yaml.ParseComposeFile(filePath)
+	return []string{filePath}
 }
 
-func selectServiceContainers(label string, prompt prompt.Prompt, services map[string]yaml.Service) ([]docker.Container, error) {
-	promptOptions := []string{allPromptOption}
-	for name, _ := range services {
-		promptOptions = append(promptOptions, name)
+// splitComposeFileEnv splits the $COMPOSE_FILE list on $COMPOSE_PATH_SEPARATOR
+// or, when it is not set, on the path list separator of the OS.
+func splitComposeFileEnv(env string) []string {
+	var paths []string
+	if separator := os.Getenv(composePathSeparatorEnv); separator != "" {
+		paths = strings.Split(env, separator)
+	} else {
+		paths = filepath.SplitList(env)
 	}
 
-	selectedServices, err := prompt.SelectPrompt(label, promptOptions)
+	var nonEmpty []string
+	for _, path := range paths {
+		if path != "" {
+			nonEmpty = append(nonEmpty, path)
+		}
+	}
+
+	return nonEmpty
+}
+
+// parseComposeFile parses and merges the compose files and applies the project
+// name taken from the flag or the environment over the one of the files.
+func (o composeOptions) parseComposeFile(args []string) (*yaml.ComposeFile, error) {
+	paths, err := o.composeFilePaths(args)
 	if err != nil {
 		return nil, err
 	}
 
-	return selectedServicesToContainers(selectedServices, services), nil
+	composeFile, err := yaml.ParseComposeFiles(paths...)
+	if err != nil {
+		return nil, err
+	}
+
+	projectName := o.projectName
+	if projectName == "" {
+		projectName = os.Getenv(projectNameEnv)
+	}
+
+	if projectName != "" {
+		if err := composeFile.SetProjectName(projectName); err != nil {
+			return nil, err
+		}
+	}
+
+	return composeFile, nil
 }
 
-func selectedServicesToContainers(selectedServices []string, services map[string]yaml.Service) []docker.Container {
-	var containers []docker.Container
+// serviceSelection holds the flags which select services without prompting.
+type serviceSelection struct {
+	services []string
+	exclude  []string
+	all      bool
+}
+
+func addServiceSelectionFlags(cmd *cobra.Command, selection *serviceSelection) {
+	cmd.Flags().StringArrayVarP(&selection.services, "service", "s", nil, "Service to select, can be repeated")
+	cmd.Flags().StringArrayVar(&selection.exclude, "exclude", nil, "Service to leave out of the selection, can be repeated")
+	cmd.Flags().BoolVar(&selection.all, "all", false, "Select all services")
+}
+
+func (s serviceSelection) isSet() bool {
+	return s.all || len(s.services) > 0 || len(s.exclude) > 0
+}
+
+// resolve returns the services selected by the flags. Excluding services
+// without naming any selects all the remaining ones.
+func (s serviceSelection) resolve(composeFile *yaml.ComposeFile) ([]string, error) {
+	for _, name := range append(append([]string{}, s.services...), s.exclude...) {
+		if _, ok := composeFile.Services[name]; !ok {
+			return nil, fmt.Errorf("service %s is not defined", name)
+		}
+	}
+
+	selected := s.services
+	if s.all || len(s.services) == 0 {
+		selected = sortedServiceNames(composeFile)
+	}
+
+	excluded := make(map[string]bool, len(s.exclude))
+	for _, name := range s.exclude {
+		excluded[name] = true
+	}
+
+	var services []string
+	for _, name := range selected {
+		if !excluded[name] {
+			services = append(services, name)
+		}
+	}
+
+	return services, nil
+}
+
+// selectServiceContainers selects services either from the flags or, when none are set, through
+// a prompt and returns their containers ordered by dependencies. The dependencies of the selected
+// services are included when requested.
+func selectServiceContainers(label string, servicePrompt prompt.Prompt, composeFile *yaml.ComposeFile, selection serviceSelection, withDependencies bool) ([]docker.Container, error) {
+	if selection.isSet() {
+		selectedServices, err := selection.resolve(composeFile)
+		if err != nil {
+			return nil, err
+		}
+
+		return selectedServicesToContainers(selectedServices, composeFile, withDependencies)
+	}
+
+	promptOptions := append([]string{allPromptOption}, sortedServiceNames(composeFile)...)
+
+	selectedServices, err := servicePrompt.SelectPrompt(label, promptOptions)
+	if errors.Is(err, prompt.ErrNotTerminal) {
+		return nil, fmt.Errorf("%w, use --service, --exclude or --all to select services", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return selectedServicesToContainers(selectedServices, composeFile, withDependencies)
+}
+
+func selectedServicesToContainers(selectedServices []string, composeFile *yaml.ComposeFile, withDependencies bool) ([]docker.Container, error) {
+	selected := make(map[string]bool)
+	var serviceNames []string
 	for _, serviceName := range selectedServices {
 		if serviceName == allPromptOption {
-			for name, service := range services {
-				containers = append(containers, newDockerContainer(name, service))
-			}
+			serviceNames = sortedServiceNames(composeFile)
 			break
 		}
 
-		if val, ok := services[serviceName]; ok {
-			containers = append(containers, newDockerContainer(serviceName, val))
+		if _, ok := composeFile.Services[serviceName]; ok {
+			serviceNames = append(serviceNames, serviceName)
 		}
 	}
 
-	return containers
+	for _, serviceName := range serviceNames {
+		selected[serviceName] = true
+	}
+
+	orderedServices, err := composeFile.ResolveDependencies(serviceNames)
+	if err != nil {
+		return nil, err
+	}
+
+	var containers []docker.Container
+	for _, serviceName := range orderedServices {
+		if !withDependencies && !selected[serviceName] {
+			continue
+		}
+
+		containers = append(containers, newDockerContainer(serviceName, composeFile.Services[serviceName], composeFile))
+	}
+
+	return containers, nil
+}
+
+func sortedServiceNames(composeFile *yaml.ComposeFile) []string {
+	names := make([]string, 0, len(composeFile.Services))
+	for name := range composeFile.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// pullPolicies maps the pull policies of the services to the ones of the containers,
+// "if_not_present" being an alias of "missing".
+var pullPolicies = map[yaml.PullPolicy]string{
+	yaml.PullPolicyAlways:       docker.PullPolicyAlways,
+	yaml.PullPolicyMissing:      docker.PullPolicyMissing,
+	yaml.PullPolicyIfNotPresent: docker.PullPolicyMissing,
+	yaml.PullPolicyNever:        docker.PullPolicyNever,
+	yaml.PullPolicyBuild:        docker.PullPolicyBuild,
 }
 
-func newDockerContainer(name string, service yaml.Service) docker.Container {
+func newDockerContainer(name string, service yaml.Service, composeFile *yaml.ComposeFile) docker.Container {
+	keys := make([]string, 0, len(service.EnvironmentVars))
+	for key := range service.EnvironmentVars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
 	var envs []string
-	for key, value := range service.EnvironmentVars {
-		envs = append(envs, key+"="+value)
+	for _, key := range keys {
+		envs = append(envs, key+"="+service.EnvironmentVars[key])
+	}
+
+	mounts, volumes := newDockerMounts(service.Volumes, composeFile)
+
+	return docker.Container{
+		Name:            composeFile.ContainerName(name),
+		Project:         composeFile.Name,
+		Service:         name,
+		ConfigFiles:     composeFile.ConfigFiles,
+		Image:           composeFile.ImageName(name),
+		Build:           newDockerBuild(service.Build),
+		PullPolicy:      pullPolicies[service.PullPolicy],
+		Command:         service.Command,
+		Entrypoint:      service.Entrypoint,
+		WorkingDir:      service.WorkingDir,
+		User:            service.User,
+		Hostname:        service.Hostname,
+		Tty:             service.Tty,
+		StdinOpen:       service.StdinOpen,
+		RestartPolicy:   newDockerRestartPolicy(service.Restart),
+		Resources:       newDockerResources(service),
+		Privileged:      service.Privileged,
+		CapAdd:          service.CapAdd,
+		CapDrop:         service.CapDrop,
+		ReadOnly:        service.ReadOnly,
+		SecurityOpt:     service.SecurityOpt,
+		Tmpfs:           newDockerTmpfs(service.Tmpfs),
+		Sysctls:         service.Sysctls,
+		Ulimits:         newDockerUlimits(service.Ulimits),
+		Labels:          service.Labels,
+		ExtraHosts:      service.ExtraHosts,
+		DNS:             service.DNS,
+		DNSSearch:       service.DNSSearch,
+		Domainname:      service.Domainname,
+		Logging:         newDockerLogging(service.Logging),
+		StopSignal:      service.StopSignal,
+		StopGracePeriod: service.StopGracePeriod,
+		EnvironmentVars: envs,
+		Ports:           newDockerPorts(service.Ports),
+		Mounts:          mounts,
+		Volumes:         volumes,
+		Networks:        newDockerNetworks(name, service.Networks, composeFile),
+		Healthcheck:     newDockerHealthcheck(service.HealthCheck),
+		DependsOn:       newDockerDependencies(service.DependsOn, composeFile),
+	}
+}
+
+// newDockerNetworks connects the service to its networks, or to the project default network when
+// none are declared. The service name is added as an alias so that services can reach each other by name.
+func newDockerNetworks(name string, serviceNetworks yaml.ServiceNetworks, composeFile *yaml.ComposeFile) []docker.Network {
+	if len(serviceNetworks) == 0 {
+		serviceNetworks = yaml.ServiceNetworks{yaml.DefaultNetwork: {}}
+	}
+
+	keys := make([]string, 0, len(serviceNetworks))
+	for key := range serviceNetworks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var networks []docker.Network
+	for _, key := range keys {
+		definition := composeFile.Networks[key]
+		networks = append(networks, docker.Network{
+			Name:       composeFile.NetworkName(key),
+			Project:    composeFile.Name,
+			Driver:     definition.Driver,
+			DriverOpts: definition.DriverOpts,
+			Labels:     definition.Labels,
+			External:   definition.External,
+			Internal:   definition.Internal,
+			Attachable: definition.Attachable,
+			Aliases:    append([]string{name}, serviceNetworks[key].Aliases...),
+		})
+	}
+
+	return networks
+}
+
+func newDockerRestartPolicy(restartPolicy *yaml.RestartPolicy) *docker.RestartPolicy {
+	if restartPolicy == nil {
+		return nil
+	}
+
+	return &docker.RestartPolicy{
+		Name:              restartPolicy.Name,
+		MaximumRetryCount: restartPolicy.MaximumRetryCount,
+	}
+}
+
+// newDockerResources returns the resource limits of the service, the ones declared
+// in deploy.resources take precedence over the service level attributes.
+func newDockerResources(service yaml.Service) *docker.Resources {
+	memory, memoryReservation, cpus, pidsLimit := service.MemLimit, service.MemReservation, service.CPUs, service.PidsLimit
+	if service.Deploy != nil {
+		if limits := service.Deploy.Resources.Limits; limits != nil {
+			if limits.Memory != 0 {
+				memory = limits.Memory
+			}
+			if limits.CPUs != 0 {
+				cpus = limits.CPUs
+			}
+			if limits.Pids != 0 {
+				pidsLimit = limits.Pids
+			}
+		}
+		if reservations := service.Deploy.Resources.Reservations; reservations != nil && reservations.Memory != 0 {
+			memoryReservation = reservations.Memory
+		}
+	}
+
+	resources := docker.Resources{
+		Memory:            int64(memory),
+		MemoryReservation: int64(memoryReservation),
+		NanoCPUs:          int64(math.Round(float64(cpus) * 1e9)),
+		CPUShares:         service.CPUShares,
+		PidsLimit:         pidsLimit,
+		ShmSize:           int64(service.ShmSize),
+	}
+	if resources == (docker.Resources{}) {
+		return nil
+	}
+
+	return &resources
+}
+
+// newDockerTmpfs maps the tmpfs mount points to their options, e.g. "/run:size=64m" to "/run": "size=64m".
+func newDockerTmpfs(serviceTmpfs yaml.StringList) map[string]string {
+	if len(serviceTmpfs) == 0 {
+		return nil
+	}
+
+	tmpfs := make(map[string]string, len(serviceTmpfs))
+	for _, mount := range serviceTmpfs {
+		target, options, _ := strings.Cut(mount, ":")
+		tmpfs[target] = options
+	}
+
+	return tmpfs
+}
+
+func newDockerUlimits(serviceUlimits map[string]yaml.Ulimit) []docker.Ulimit {
+	names := make([]string, 0, len(serviceUlimits))
+	for name := range serviceUlimits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var ulimits []docker.Ulimit
+	for _, name := range names {
+		ulimits = append(ulimits, docker.Ulimit{Name: name, Soft: serviceUlimits[name].Soft, Hard: serviceUlimits[name].Hard})
+	}
+
+	return ulimits
+}
+
+func newDockerLogging(logging *yaml.Logging) *docker.Logging {
+	if logging == nil {
+		return nil
+	}
+
+	return &docker.Logging{Driver: logging.Driver, Options: logging.Options}
+}
+
+func newDockerBuild(build *yaml.Build) *docker.Build {
+	if build == nil {
+		return nil
+	}
+
+	return &docker.Build{
+		Context:    build.Context,
+		Dockerfile: build.Dockerfile,
+		Args:       build.Args,
+		Target:     build.Target,
+		Labels:     build.Labels,
+		CacheFrom:  build.CacheFrom,
+	}
+}
+
+func newDockerHealthcheck(healthCheck *yaml.HealthCheck) *docker.Healthcheck {
+	if healthCheck == nil {
+		return nil
+	}
+
+	if healthCheck.Disable {
+		return &docker.Healthcheck{Test: []string{"NONE"}}
+	}
+
+	return &docker.Healthcheck{
+		Test:        healthCheck.Test,
+		Interval:    healthCheck.Interval,
+		Timeout:     healthCheck.Timeout,
+		StartPeriod: healthCheck.StartPeriod,
+		Retries:     healthCheck.Retries,
+	}
+}
+
+// dependencyConditions maps the conditions of the dependencies to the ones containers are waited for.
+var dependencyConditions = map[string]string{
+	yaml.ConditionServiceStarted:               docker.ConditionStarted,
+	yaml.ConditionServiceHealthy:               docker.ConditionHealthy,
+	yaml.ConditionServiceCompletedSuccessfully: docker.ConditionCompleted,
+}
+
+func newDockerDependencies(serviceDependencies yaml.ServiceDependencies, composeFile *yaml.ComposeFile) map[string]string {
+	if len(serviceDependencies) == 0 {
+		return nil
+	}
+
+	dependencies := make(map[string]string, len(serviceDependencies))
+	for name, dependency := range serviceDependencies {
+		dependencies[composeFile.ContainerName(name)] = dependencyConditions[dependency.Condition]
+	}
+
+	return dependencies
+}
+
+func newDockerPorts(servicePorts yaml.ServicePorts) []docker.Port {
+	var ports []docker.Port
+	for _, servicePort := range servicePorts {
+		ports = append(ports, docker.Port{
+			HostIP:        servicePort.HostIP,
+			HostPort:      servicePort.Published,
+			ContainerPort: strconv.FormatUint(uint64(servicePort.Target), 10),
+			Protocol:      servicePort.Protocol,
+		})
+	}
+
+	return ports
+}
+
+func newDockerMounts(serviceVolumes yaml.ServiceVolumes, composeFile *yaml.ComposeFile) ([]docker.Mount, []docker.Volume) {
+	var mounts []docker.Mount
+	var volumes []docker.Volume
+	for _, serviceVolume := range serviceVolumes {
+		mount := docker.Mount{
+			Type:     serviceVolume.Type,
+			Source:   serviceVolume.Source,
+			Target:   serviceVolume.Target,
+			ReadOnly: serviceVolume.ReadOnly,
+		}
+
+		if serviceVolume.Bind != nil {
+			mount.Propagation = serviceVolume.Bind.Propagation
+		}
+
+		if serviceVolume.Volume != nil {
+			mount.NoCopy = serviceVolume.Volume.NoCopy
+		}
+
+		if definition, ok := composeFile.Volumes[serviceVolume.Source]; ok && serviceVolume.Type == yaml.VolumeTypeVolume {
+			volume := docker.Volume{
+				Name:       composeFile.VolumeName(serviceVolume.Source),
+				Project:    composeFile.Name,
+				Driver:     definition.Driver,
+				DriverOpts: definition.DriverOpts,
+				Labels:     definition.Labels,
+				External:   definition.External,
+			}
+
+			mount.Source = volume.Name
+			volumes = append(volumes, volume)
+		}
+
+		mounts = append(mounts, mount)
 	}
 
-	return docker.Container{Name: name, Image: service.Image, EnvironmentVars: envs}
+	return mounts, volumes
 }
@@ -2,39 +2,164 @@ package command
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/petrovskiborislav/docker-cli/docker"
 	"github.com/petrovskiborislav/docker-cli/logger"
 	"github.com/petrovskiborislav/docker-cli/prompt"
+	"github.com/petrovskiborislav/docker-cli/yaml"
 )
 
+const defaultWaitTimeout = 2 * time.Minute
+
 // NewStartCommand creates start command which reads
 // compose file and starts the selected services.
 func NewStartCommand(ctx context.Context, logger logger.Logger, prompt prompt.Prompt, client docker.Client) *cobra.Command {
-	return &cobra.Command{
-		Use:   "start [PATH to docker-compose file]",
-		Short: "Starts the selected services listed from the specified compose file",
-		Run: func(cmd *cobra.Command, args []string) {
-			parsedServices, err := parseComposeFile(args)
-			if err != nil {
-				logger.Error("Error parsing compose file: %s\n", err)
-				return
+	compose := composeOptions{logger: logger}
+	var selection serviceSelection
+	var wait bool
+	var waitTimeout time.Duration
+	var options docker.ProvisioningOptions
+
+	cmd := &cobra.Command{
+		Use:           "start [PATH to docker-compose file]",
+		Short:         "Starts the selected services listed from the specified compose file",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	cmd.RunE = func(_ *cobra.Command, args []string) error {
+		pullPolicy, err := pullFlagPolicy(options.PullPolicy)
+		if err != nil {
+			logger.Error("Error starting services: %s\n", err)
+			return err
+		}
+		options.PullPolicy = pullPolicy
+
+		composeFile, err := compose.parseComposeFile(args)
+		if err != nil {
+			logger.Error("Error parsing compose file: %s\n", err)
+			return err
+		}
+
+		selectedServiceContainers, err := selectServiceContainers("Select services to start", prompt, composeFile, selection, true)
+		if err != nil {
+			logger.Error("Error selecting services: %s\n", err)
+			return err
+		}
+
+		for _, serviceContainer := range selectedServiceContainers {
+			if err := waitForDependencies(ctx, client, serviceContainer, waitTimeout); err != nil {
+				logger.Error("Error waiting for dependencies: %s\n", err)
+				return err
 			}
 
-			selectedServiceContainers, err := selectServiceContainers("Select services to start", prompt, parsedServices)
-			if err != nil {
-				logger.Error("Error selecting services: %s\n", err)
-				return
+			if err := client.ServiceProvisioning(ctx, serviceContainer, options); err != nil {
+				logger.Error("Error starting services: %s\n", err)
+				return err
 			}
+		}
 
-			for _, serviceContainer := range selectedServiceContainers {
-				if err := client.ServiceProvisioning(ctx, serviceContainer); err != nil {
-					logger.Error("Error starting services: %s\n", err)
-					return
-				}
+		if !wait {
+			return nil
+		}
+
+		// Scripts rely on the exit status to know whether the services became ready.
+		containerNamesByCondition := readinessConditions(selectedServiceContainers)
+		for _, condition := range []string{docker.ConditionCompleted, docker.ConditionHealthy} {
+			containerNames := containerNamesByCondition[condition]
+			if len(containerNames) == 0 {
+				continue
 			}
-		},
+
+			if err := client.WaitForServices(ctx, containerNames, condition, waitTimeout); err != nil {
+				logger.Error("Error waiting for services: %s\n", err)
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	addComposeFlags(cmd, &compose)
+	addServiceSelectionFlags(cmd, &selection)
+	cmd.Flags().BoolVar(&wait, "wait", false, "Wait for the services to be running and healthy, or completed when others depend on their completion")
+	cmd.Flags().DurationVar(&waitTimeout, "wait-timeout", defaultWaitTimeout, "Maximum duration to wait for services to become ready")
+	cmd.Flags().BoolVar(&options.ForceRecreate, "force-recreate", false, "Recreate containers even if their configuration has not changed")
+	cmd.Flags().BoolVar(&options.NoRecreate, "no-recreate", false, "Keep existing containers even if their configuration has changed")
+	cmd.MarkFlagsMutuallyExclusive("force-recreate", "no-recreate")
+	cmd.Flags().StringVar(&options.PullPolicy, "pull", "", "Pull images before starting: always, missing (or if_not_present) or never, overrides pull_policy of services which are not built, build rebuilds the images of services which are built")
+
+	return cmd
+}
+
+// pullFlagPolicy returns the pull policy given to --pull, which can be left empty. It accepts
+// the same values as pull_policy.
+func pullFlagPolicy(value string) (string, error) {
+	if value == "" {
+		return "", nil
 	}
+
+	pullPolicy, ok := pullPolicies[yaml.PullPolicy(value)]
+	if !ok {
+		return "", fmt.Errorf("invalid pull policy %q: it must be %s, %s, %s, %s or %s", value,
+			yaml.PullPolicyAlways, yaml.PullPolicyMissing, yaml.PullPolicyIfNotPresent, yaml.PullPolicyNever, yaml.PullPolicyBuild)
+	}
+
+	return pullPolicy, nil
+}
+
+// readinessConditions groups the started containers by the condition they have to satisfy to be ready.
+// Containers other services depend on with service_completed_successfully run once and have to complete,
+// the others have to be running and healthy.
+func readinessConditions(containers []docker.Container) map[string][]string {
+	completed := make(map[string]bool)
+	for _, container := range containers {
+		for name, condition := range container.DependsOn {
+			if condition == docker.ConditionCompleted {
+				completed[name] = true
+			}
+		}
+	}
+
+	containerNamesByCondition := make(map[string][]string)
+	for _, container := range containers {
+		condition := docker.ConditionHealthy
+		if completed[container.Name] {
+			condition = docker.ConditionCompleted
+		}
+		containerNamesByCondition[condition] = append(containerNamesByCondition[condition], container.Name)
+	}
+
+	return containerNamesByCondition
+}
+
+// waitForDependencies blocks until the dependencies of the container satisfy their conditions.
+// Dependencies which only need to be started are already running as containers are started in order.
+func waitForDependencies(ctx context.Context, client docker.Client, container docker.Container, timeout time.Duration) error {
+	dependenciesByCondition := make(map[string][]string)
+	for name, condition := range container.DependsOn {
+		if condition == docker.ConditionStarted {
+			continue
+		}
+		dependenciesByCondition[condition] = append(dependenciesByCondition[condition], name)
+	}
+
+	for _, condition := range []string{docker.ConditionCompleted, docker.ConditionHealthy} {
+		dependencies := dependenciesByCondition[condition]
+		if len(dependencies) == 0 {
+			continue
+		}
+
+		sort.Strings(dependencies)
+		if err := client.WaitForServices(ctx, dependencies, condition, timeout); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
@@ -3,7 +3,9 @@ package command_test
 import (
 	"context"
 	"errors"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/mock"
@@ -42,26 +44,25 @@ func (s *startTestSuite) TestStart_WhenAllServicesSelected_ThenSuccess() {
 
 	msg := "Select services to start"
 	items := []string{"all", "nginx", "db", "cache", "wordpress"}
-	serviceContainer1 := docker.Container{Name: "nginx", Image: "nginx:alpine"}
-	serviceContainer2 := docker.Container{
-		Name:            "db",
-		Image:           "mysql:latest",
-		EnvironmentVars: []string{"MYSQL_ALLOW_EMPTY_PASSWORD=true"}}
-	serviceContainer3 := docker.Container{Name: "cache", Image: "memcached"}
-	serviceContainer4 := docker.Container{Name: "wordpress", Image: "wordpress:6.0"}
+	serviceContainer1 := nginxContainer()
+	serviceContainer2 := dbContainer()
+	serviceContainer3 := cacheContainer()
+	serviceContainer4 := wordpressContainer()
 
 	matcher := mock.MatchedBy(matchElements(items))
 	s.prompt.On("SelectPrompt", msg, matcher).Return(items[0:1], nil)
 
-	s.client.On("ServiceProvisioning", ctx, serviceContainer1).Return(nil).Once()
-	s.client.On("ServiceProvisioning", ctx, serviceContainer2).Return(nil).Once()
-	s.client.On("ServiceProvisioning", ctx, serviceContainer3).Return(nil).Once()
-	s.client.On("ServiceProvisioning", ctx, serviceContainer4).Return(nil).Once()
+	s.client.On("ServiceProvisioning", ctx, serviceContainer1, docker.ProvisioningOptions{}).Return(nil).Once()
+	s.client.On("ServiceProvisioning", ctx, serviceContainer2, docker.ProvisioningOptions{}).Return(nil).Once()
+	s.client.On("ServiceProvisioning", ctx, serviceContainer3, docker.ProvisioningOptions{}).Return(nil).Once()
+	s.client.On("ServiceProvisioning", ctx, serviceContainer4, docker.ProvisioningOptions{}).Return(nil).Once()
+	s.client.On("WaitForServices", ctx, []string{"docker-cli-db"}, docker.ConditionHealthy, 2*time.Minute).Return(nil).Once()
 
 	// Act
-	s.sut.Run(nil, []string{filePath})
+	err := s.sut.RunE(nil, []string{filePath})
 
 	// Assert
+	s.NoError(err)
 	s.prompt.AssertExpectations(s.T())
 	s.client.AssertExpectations(s.T())
 }
@@ -72,17 +73,193 @@ func (s *startTestSuite) TestStart_WhenSingleServiceSelected_ThenSuccess() {
 
 	msg := "Select services to start"
 	items := []string{"all", "nginx", "db", "cache", "wordpress"}
-	serviceContainer := docker.Container{Name: "nginx", Image: "nginx:alpine"}
+	serviceContainer := nginxContainer()
 
 	matcher := mock.MatchedBy(matchElements(items))
 	s.prompt.On("SelectPrompt", msg, matcher).Return(items[1:2], nil)
 
-	s.client.On("ServiceProvisioning", ctx, serviceContainer).Return(nil)
+	s.client.On("ServiceProvisioning", ctx, serviceContainer, docker.ProvisioningOptions{}).Return(nil)
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.NoError(err)
+	s.prompt.AssertExpectations(s.T())
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *startTestSuite) TestStart_WhenServiceWithDependenciesSelected_ThenDependenciesStartFirst() {
+	// Arrange
+	ctx := context.Background()
+
+	msg := "Select services to start"
+	items := []string{"all", "nginx", "db", "cache", "wordpress"}
+	serviceContainer1 := dbContainer()
+	serviceContainer2 := wordpressContainer()
+
+	matcher := mock.MatchedBy(matchElements(items))
+	s.prompt.On("SelectPrompt", msg, matcher).Return(items[4:5], nil)
+
+	s.client.On("ServiceProvisioning", ctx, serviceContainer1, docker.ProvisioningOptions{}).Return(nil).Once()
+	s.client.On("WaitForServices", ctx, []string{"docker-cli-db"}, docker.ConditionHealthy, 2*time.Minute).Return(nil).Once()
+	s.client.On("ServiceProvisioning", ctx, serviceContainer2, docker.ProvisioningOptions{}).Return(nil).Once()
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.NoError(err)
+	s.prompt.AssertExpectations(s.T())
+	s.client.AssertExpectations(s.T())
+	s.Equal(serviceContainer1, s.client.Calls[0].Arguments.Get(1))
+	s.Equal("WaitForServices", s.client.Calls[1].Method)
+	s.Equal(serviceContainer2, s.client.Calls[2].Arguments.Get(1))
+}
+
+func (s *startTestSuite) TestStart_WhenDependencyDoesNotBecomeHealthy_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+
+	msg := "Select services to start"
+	items := []string{"all", "nginx", "db", "cache", "wordpress"}
+	serviceContainer := dbContainer()
+
+	matcher := mock.MatchedBy(matchElements(items))
+	s.prompt.On("SelectPrompt", msg, matcher).Return(items[4:5], nil)
+
+	s.client.On("ServiceProvisioning", ctx, serviceContainer, docker.ProvisioningOptions{}).Return(nil).Once()
+	s.client.On("WaitForServices", ctx, []string{"docker-cli-db"}, docker.ConditionHealthy, 2*time.Minute).Return(errors.New("error")).Once()
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.Error(err)
+	s.prompt.AssertExpectations(s.T())
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *startTestSuite) TestStart_WhenWaitFlagIsSet_ThenWaitsForServices() {
+	// Arrange
+	ctx := context.Background()
+
+	msg := "Select services to start"
+	items := []string{"all", "nginx", "db", "cache", "wordpress"}
+	serviceContainer1 := nginxContainer()
+	serviceContainer2 := cacheContainer()
+
+	matcher := mock.MatchedBy(matchElements(items))
+	s.prompt.On("SelectPrompt", msg, matcher).Return([]string{"nginx", "cache"}, nil)
+
+	s.client.On("ServiceProvisioning", ctx, serviceContainer1, docker.ProvisioningOptions{}).Return(nil).Once()
+	s.client.On("ServiceProvisioning", ctx, serviceContainer2, docker.ProvisioningOptions{}).Return(nil).Once()
+	s.client.On("WaitForServices", ctx, []string{"docker-cli-nginx", "docker-cli-cache"}, docker.ConditionHealthy, 30*time.Second).Return(nil).Once()
+
+	s.Require().NoError(s.sut.Flags().Set("wait", "true"))
+	s.Require().NoError(s.sut.Flags().Set("wait-timeout", "30s"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.NoError(err)
+	s.prompt.AssertExpectations(s.T())
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *startTestSuite) TestStart_WhenWaitingForOneShotDependency_ThenItHasToComplete() {
+	// Arrange
+	ctx := context.Background()
+
+	s.client.On("ServiceProvisioning", ctx, mock.Anything, docker.ProvisioningOptions{}).Return(nil).Twice()
+	s.client.On("WaitForServices", ctx, []string{"testdata-migrate"}, docker.ConditionCompleted, 30*time.Second).Return(nil).Twice()
+	s.client.On("WaitForServices", ctx, []string{"testdata-api"}, docker.ConditionHealthy, 30*time.Second).Return(nil).Once()
+
+	s.Require().NoError(s.sut.Flags().Set("all", "true"))
+	s.Require().NoError(s.sut.Flags().Set("wait", "true"))
+	s.Require().NoError(s.sut.Flags().Set("wait-timeout", "30s"))
 
 	// Act
-	s.sut.Run(nil, []string{filePath})
+	err := s.sut.RunE(nil, []string{"testdata/one-shot-compose.yaml"})
 
 	// Assert
+	s.NoError(err)
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *startTestSuite) TestStart_WhenServicesDoNotBecomeReady_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+
+	s.client.On("ServiceProvisioning", ctx, cacheContainer(), docker.ProvisioningOptions{}).Return(nil).Once()
+	s.client.On("WaitForServices", ctx, []string{"docker-cli-cache"}, docker.ConditionHealthy, 30*time.Second).Return(errors.New("timeout")).Once()
+
+	s.Require().NoError(s.sut.Flags().Set("service", "cache"))
+	s.Require().NoError(s.sut.Flags().Set("wait", "true"))
+	s.Require().NoError(s.sut.Flags().Set("wait-timeout", "30s"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.Error(err)
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *startTestSuite) TestStart_WhenServicesSelectedWithFlags_ThenPromptIsSkipped() {
+	// Arrange
+	ctx := context.Background()
+	serviceContainer1 := nginxContainer()
+	serviceContainer2 := cacheContainer()
+
+	s.client.On("ServiceProvisioning", ctx, serviceContainer1, docker.ProvisioningOptions{}).Return(nil).Once()
+	s.client.On("ServiceProvisioning", ctx, serviceContainer2, docker.ProvisioningOptions{}).Return(nil).Once()
+
+	s.Require().NoError(s.sut.Flags().Set("service", "nginx"))
+	s.Require().NoError(s.sut.Flags().Set("service", "cache"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.NoError(err)
+	s.prompt.AssertExpectations(s.T())
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *startTestSuite) TestStart_WhenServicesExcludedWithFlags_ThenRemainingServicesStart() {
+	// Arrange
+	ctx := context.Background()
+	serviceContainer1 := cacheContainer()
+	serviceContainer2 := nginxContainer()
+
+	s.client.On("ServiceProvisioning", ctx, serviceContainer1, docker.ProvisioningOptions{}).Return(nil).Once()
+	s.client.On("ServiceProvisioning", ctx, serviceContainer2, docker.ProvisioningOptions{}).Return(nil).Once()
+
+	s.Require().NoError(s.sut.Flags().Set("exclude", "db"))
+	s.Require().NoError(s.sut.Flags().Set("exclude", "wordpress"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.NoError(err)
+	s.prompt.AssertExpectations(s.T())
+	s.client.AssertExpectations(s.T())
+	s.Equal(serviceContainer1, s.client.Calls[0].Arguments.Get(1))
+	s.Equal(serviceContainer2, s.client.Calls[1].Arguments.Get(1))
+}
+
+func (s *startTestSuite) TestStart_WhenSelectedServiceIsUndefined_ThenFailure() {
+	// Arrange
+	s.Require().NoError(s.sut.Flags().Set("service", "unknown"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.Error(err)
 	s.prompt.AssertExpectations(s.T())
 	s.client.AssertExpectations(s.T())
 }
@@ -91,9 +268,10 @@ func (s *startTestSuite) TestStart_WhenErrorOccursOnParsing_ThenFailure() {
 	// Arrange
 
 	// Act
-	s.sut.Run(nil, []string{""})
+	err := s.sut.RunE(nil, []string{""})
 
 	// Assert
+	s.Error(err)
 	s.prompt.AssertExpectations(s.T())
 	s.client.AssertExpectations(s.T())
 }
@@ -107,34 +285,261 @@ func (s *startTestSuite) TestStart_WhenErrorOccursOnSelectingServices_ThenFailur
 	s.prompt.On("SelectPrompt", msg, matcher).Return(nil, errors.New("error"))
 
 	// Act
-	s.sut.Run(nil, []string{})
+	err := s.sut.RunE(nil, []string{filePath})
 
 	// Assert
+	s.Error(err)
 	s.prompt.AssertExpectations(s.T())
 	s.client.AssertExpectations(s.T())
 }
 
+func (s *startTestSuite) TestStart_WhenProjectNameIsSet_ThenResourcesArePrefixed() {
+	// Arrange
+	ctx := context.Background()
+	container := dbContainer()
+	container.Name = "feature-db"
+	container.Project = "feature"
+	container.Mounts[0].Source = "feature_db-data"
+	container.Volumes = []docker.Volume{{Name: "feature_db-data", Project: "feature"}}
+	container.Networks = []docker.Network{{Name: "feature_default", Project: "feature", Aliases: []string{"db"}}}
+
+	s.client.On("ServiceProvisioning", ctx, container, docker.ProvisioningOptions{}).Return(nil).Once()
+
+	s.Require().NoError(s.sut.Flags().Set("service", "db"))
+	s.Require().NoError(s.sut.Flags().Set("project-name", "feature"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.NoError(err)
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *startTestSuite) TestStart_WhenProjectNameEnvIsSet_ThenResourcesArePrefixed() {
+	// Arrange
+	ctx := context.Background()
+	container := cacheContainer()
+	container.Name = "branch-cache"
+	container.Project = "branch"
+	container.Networks = []docker.Network{{Name: "branch_default", Project: "branch", Aliases: []string{"cache"}}}
+
+	s.client.On("ServiceProvisioning", ctx, container, docker.ProvisioningOptions{}).Return(nil).Once()
+
+	s.T().Setenv("COMPOSE_PROJECT_NAME", "branch")
+	s.Require().NoError(s.sut.Flags().Set("service", "cache"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.NoError(err)
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *startTestSuite) TestStart_WhenProjectNameIsInvalid_ThenFailure() {
+	// Arrange
+	s.Require().NoError(s.sut.Flags().Set("service", "cache"))
+	s.Require().NoError(s.sut.Flags().Set("project-name", "Invalid Name"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.Error(err)
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *startTestSuite) TestStart_WhenRecreateFlagIsSet_ThenOptionsArePassed() {
+	// Arrange
+	ctx := context.Background()
+	options := docker.ProvisioningOptions{ForceRecreate: true}
+
+	s.client.On("ServiceProvisioning", ctx, cacheContainer(), options).Return(nil).Once()
+
+	s.Require().NoError(s.sut.Flags().Set("service", "cache"))
+	s.Require().NoError(s.sut.Flags().Set("force-recreate", "true"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.NoError(err)
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *startTestSuite) TestStart_WhenComposeFilesAreRepeated_ThenFilesAreMerged() {
+	// Arrange
+	ctx := context.Background()
+	overridePath := "testdata/cache-override-compose.yaml"
+	absOverridePath, _ := filepath.Abs(overridePath)
+
+	container := cacheContainer()
+	container.Image = "memcached:alpine"
+	container.ConfigFiles = append(configFiles(), absOverridePath)
+
+	s.client.On("ServiceProvisioning", ctx, container, docker.ProvisioningOptions{}).Return(nil).Once()
+
+	s.Require().NoError(s.sut.Flags().Set("file", filePath))
+	s.Require().NoError(s.sut.Flags().Set("file", overridePath))
+	s.Require().NoError(s.sut.Flags().Set("service", "cache"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{})
+
+	// Assert
+	s.NoError(err)
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *startTestSuite) TestStart_WhenResourcesAreLimited_ThenDeployResourcesTakePrecedence() {
+	// Arrange
+	ctx := context.Background()
+	resourcesPath := "testdata/resources-compose.yaml"
+	absResourcesPath, _ := filepath.Abs(resourcesPath)
+
+	container := docker.Container{
+		Name:          "docker-cli-api",
+		Project:       "docker-cli",
+		Service:       "api",
+		ConfigFiles:   []string{absResourcesPath},
+		Image:         "golang",
+		RestartPolicy: &docker.RestartPolicy{Name: "always"},
+		Resources: &docker.Resources{
+			Memory:            512 * 1024 * 1024,
+			MemoryReservation: 128 * 1024 * 1024,
+			NanoCPUs:          500000000,
+		},
+		Networks: []docker.Network{{Name: "docker-cli_default", Project: "docker-cli", Aliases: []string{"api"}}},
+	}
+
+	s.client.On("ServiceProvisioning", ctx, container, docker.ProvisioningOptions{}).Return(nil).Once()
+	s.Require().NoError(s.sut.Flags().Set("all", "true"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{resourcesPath})
+
+	// Assert
+	s.NoError(err)
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *startTestSuite) TestStart_WhenSecurityOptionsAreSet_ThenContainerIsLockedDown() {
+	// Arrange
+	ctx := context.Background()
+	securityPath := "testdata/security-compose.yaml"
+	absSecurityPath, _ := filepath.Abs(securityPath)
+
+	container := docker.Container{
+		Name:        "docker-cli-api",
+		Project:     "docker-cli",
+		Service:     "api",
+		ConfigFiles: []string{absSecurityPath},
+		Image:       "golang",
+		ReadOnly:    true,
+		CapDrop:     []string{"ALL"},
+		Tmpfs:       map[string]string{"/run": "size=64m", "/tmp": ""},
+		Ulimits: []docker.Ulimit{
+			{Name: "nofile", Soft: 1024, Hard: 2048},
+			{Name: "nproc", Soft: 512, Hard: 512},
+		},
+		Networks: []docker.Network{{Name: "docker-cli_default", Project: "docker-cli", Aliases: []string{"api"}}},
+	}
+
+	s.client.On("ServiceProvisioning", ctx, container, docker.ProvisioningOptions{}).Return(nil).Once()
+	s.Require().NoError(s.sut.Flags().Set("all", "true"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{securityPath})
+
+	// Assert
+	s.NoError(err)
+	s.client.AssertExpectations(s.T())
+}
+
 func (s *startTestSuite) TestStart_WhenErrorOccursOnServiceProvisioning_ThenFailure() {
 	// Arrange
 	ctx := context.Background()
 
 	msg := "Select services to start"
 	items := []string{"all", "nginx", "db", "cache", "wordpress"}
-	serviceContainer := docker.Container{Name: "nginx", Image: "nginx:alpine"}
+	serviceContainer := nginxContainer()
 
 	matcher := mock.MatchedBy(matchElements(items))
 	s.prompt.On("SelectPrompt", msg, matcher).Return(items[1:2], nil)
 
-	s.client.On("ServiceProvisioning", ctx, serviceContainer).Return(errors.New("error"))
+	s.client.On("ServiceProvisioning", ctx, serviceContainer, docker.ProvisioningOptions{}).Return(errors.New("error"))
 
 	// Act
-	s.sut.Run(nil, []string{})
+	err := s.sut.RunE(nil, []string{filePath})
 
 	// Assert
+	s.Error(err)
 	s.prompt.AssertExpectations(s.T())
 	s.client.AssertExpectations(s.T())
 }
 
+func nginxContainer() docker.Container {
+	return docker.Container{
+		Name:        "docker-cli-nginx",
+		Project:     "docker-cli",
+		Service:     "nginx",
+		ConfigFiles: configFiles(),
+		Image:       "nginx:alpine",
+		Ports:       []docker.Port{{HostPort: "8080", ContainerPort: "80", Protocol: "tcp"}},
+		Networks:    []docker.Network{{Name: "docker-cli_default", Project: "docker-cli", Aliases: []string{"nginx"}}},
+	}
+}
+
+func dbContainer() docker.Container {
+	return docker.Container{
+		Name:            "docker-cli-db",
+		Project:         "docker-cli",
+		Service:         "db",
+		ConfigFiles:     configFiles(),
+		Image:           "mysql:latest",
+		EnvironmentVars: []string{"MYSQL_ALLOW_EMPTY_PASSWORD=true"},
+		Mounts:          []docker.Mount{{Type: "volume", Source: "docker-cli_db-data", Target: "/var/lib/mysql"}},
+		Volumes:         []docker.Volume{{Name: "docker-cli_db-data", Project: "docker-cli"}},
+		Networks:        []docker.Network{{Name: "docker-cli_default", Project: "docker-cli", Aliases: []string{"db"}}},
+		Healthcheck: &docker.Healthcheck{
+			Test:     []string{"CMD", "mysqladmin", "ping", "-h", "localhost"},
+			Interval: 10 * time.Second,
+			Timeout:  5 * time.Second,
+			Retries:  5,
+		},
+	}
+}
+
+func cacheContainer() docker.Container {
+	return docker.Container{
+		Name:        "docker-cli-cache",
+		Project:     "docker-cli",
+		Service:     "cache",
+		ConfigFiles: configFiles(),
+		Image:       "memcached",
+		Networks:    []docker.Network{{Name: "docker-cli_default", Project: "docker-cli", Aliases: []string{"cache"}}},
+	}
+}
+
+func wordpressContainer() docker.Container {
+	return docker.Container{
+		Name:        "docker-cli-wordpress",
+		Project:     "docker-cli",
+		Service:     "wordpress",
+		ConfigFiles: configFiles(),
+		Image:       "wordpress:6.0",
+		Ports:       []docker.Port{{HostPort: "8000", ContainerPort: "80", Protocol: "tcp"}},
+		Networks:    []docker.Network{{Name: "docker-cli_default", Project: "docker-cli", Aliases: []string{"wordpress"}}},
+		DependsOn:   map[string]string{"docker-cli-db": docker.ConditionHealthy},
+	}
+}
+
+func configFiles() []string {
+	path, _ := filepath.Abs(filePath)
+	return []string{path}
+}
+
 func matchElements(x []string) func(y []string) bool {
 	return func(y []string) bool {
 		if len(x) != len(y) {
@@ -156,3 +561,114 @@ func matchElements(x []string) func(y []string) bool {
 		return len(diff) == 0
 	}
 }
+
+func (s *startTestSuite) TestStart_WhenBuildIsDefined_ThenImageIsNamedAfterProjectAndService() {
+	// Arrange
+	ctx := context.Background()
+
+	s.client.On("ServiceProvisioning", ctx, buildContainer(), docker.ProvisioningOptions{}).Return(nil).Once()
+	s.Require().NoError(s.sut.Flags().Set("service", "api"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{"testdata/build-compose.yaml"})
+
+	// Assert
+	s.NoError(err)
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *startTestSuite) TestStart_WhenPullFlagIsSet_ThenItIsPassedToProvisioning() {
+	// Arrange
+	ctx := context.Background()
+
+	options := docker.ProvisioningOptions{PullPolicy: docker.PullPolicyAlways}
+	s.client.On("ServiceProvisioning", ctx, buildCacheContainer(), options).Return(nil).Once()
+	s.Require().NoError(s.sut.Flags().Set("service", "cache"))
+	s.Require().NoError(s.sut.Flags().Set("pull", "always"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{"testdata/build-compose.yaml"})
+
+	// Assert
+	s.NoError(err)
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *startTestSuite) TestStart_WhenPullFlagIsAnAlias_ThenItIsNormalized() {
+	// Arrange
+	ctx := context.Background()
+
+	options := docker.ProvisioningOptions{PullPolicy: docker.PullPolicyMissing}
+	s.client.On("ServiceProvisioning", ctx, buildCacheContainer(), options).Return(nil).Once()
+	s.Require().NoError(s.sut.Flags().Set("service", "cache"))
+	s.Require().NoError(s.sut.Flags().Set("pull", "if_not_present"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{"testdata/build-compose.yaml"})
+
+	// Assert
+	s.NoError(err)
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *startTestSuite) TestStart_WhenPullFlagIsBuild_ThenItIsPassedToProvisioning() {
+	// Arrange
+	ctx := context.Background()
+
+	options := docker.ProvisioningOptions{PullPolicy: docker.PullPolicyBuild}
+	s.client.On("ServiceProvisioning", ctx, buildContainer(), options).Return(nil).Once()
+	s.Require().NoError(s.sut.Flags().Set("service", "api"))
+	s.Require().NoError(s.sut.Flags().Set("pull", "build"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{"testdata/build-compose.yaml"})
+
+	// Assert
+	s.NoError(err)
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *startTestSuite) TestStart_WhenPullFlagIsInvalid_ThenNothingIsStarted() {
+	// Arrange
+	s.Require().NoError(s.sut.Flags().Set("service", "cache"))
+	s.Require().NoError(s.sut.Flags().Set("pull", "sometimes"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{"testdata/build-compose.yaml"})
+
+	// Assert
+	s.Error(err)
+	s.client.AssertNotCalled(s.T(), "ServiceProvisioning")
+}
+
+func buildContainer() docker.Container {
+	absBuildPath, _ := filepath.Abs("testdata/build-compose.yaml")
+
+	return docker.Container{
+		Name:        "docker-cli-api",
+		Project:     "docker-cli",
+		Service:     "api",
+		ConfigFiles: []string{absBuildPath},
+		Image:       "docker-cli-api",
+		Build: &docker.Build{
+			Context: filepath.Join(filepath.Dir(absBuildPath), "api"),
+			Args:    map[string]string{"GO_VERSION": "1.18"},
+			Target:  "release",
+		},
+		Networks: []docker.Network{{Name: "docker-cli_default", Project: "docker-cli", Aliases: []string{"api"}}},
+	}
+}
+
+func buildCacheContainer() docker.Container {
+	absBuildPath, _ := filepath.Abs("testdata/build-compose.yaml")
+
+	return docker.Container{
+		Name:        "docker-cli-cache",
+		Project:     "docker-cli",
+		Service:     "cache",
+		ConfigFiles: []string{absBuildPath},
+		Image:       "redis:7",
+		PullPolicy:  docker.PullPolicyAlways,
+		Networks:    []docker.Network{{Name: "docker-cli_default", Project: "docker-cli", Aliases: []string{"cache"}}},
+	}
+}
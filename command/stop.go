@@ -13,28 +13,42 @@ import (
 // NewStopCommand creates stop command which reads
 // compose file and stops the selected services.
 func NewStopCommand(ctx context.Context, logger logger.Logger, prompt prompt.Prompt, client docker.Client) *cobra.Command {
-	return &cobra.Command{
-		Use:   "stop [PATH to docker-compose file]",
-		Short: "Stops the selected services listed from the specified compose file",
-		Run: func(cmd *cobra.Command, args []string) {
-			parsedServices, err := parseComposeFile(args)
-			if err != nil {
-				logger.Error("Error parsing compose file: %s\n", err)
-				return
-			}
+	compose := composeOptions{logger: logger}
+	var selection serviceSelection
 
-			selectedServiceContainers, err := selectServiceContainers("Select services to stop", prompt, parsedServices)
-			if err != nil {
-				logger.Error("Error selecting services: %s\n", err)
-				return
-			}
+	cmd := &cobra.Command{
+		Use:           "stop [PATH to docker-compose file]",
+		Short:         "Stops the selected services listed from the specified compose file",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	cmd.RunE = func(_ *cobra.Command, args []string) error {
+		composeFile, err := compose.parseComposeFile(args)
+		if err != nil {
+			logger.Error("Error parsing compose file: %s\n", err)
+			return err
+		}
 
-			for _, serviceContainer := range selectedServiceContainers {
-				if err := client.ServiceDecommissioning(ctx, serviceContainer); err != nil {
-					logger.Error("Error stopping services: %s\n", err)
-					return
-				}
+		selectedServiceContainers, err := selectServiceContainers("Select services to stop", prompt, composeFile, selection, false)
+		if err != nil {
+			logger.Error("Error selecting services: %s\n", err)
+			return err
+		}
+
+		// Services are stopped in reverse order so that dependents go down before their dependencies.
+		for i := len(selectedServiceContainers) - 1; i >= 0; i-- {
+			if err := client.ServiceDecommissioning(ctx, selectedServiceContainers[i]); err != nil {
+				logger.Error("Error stopping services: %s\n", err)
+				return err
 			}
-		},
+		}
+
+		return nil
 	}
+
+	addComposeFlags(cmd, &compose)
+	addServiceSelectionFlags(cmd, &selection)
+
+	return cmd
 }
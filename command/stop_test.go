@@ -10,8 +10,8 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/petrovskiborislav/docker-cli/command"
-	"github.com/petrovskiborislav/docker-cli/docker"
 	"github.com/petrovskiborislav/docker-cli/logger"
+	"github.com/petrovskiborislav/docker-cli/prompt"
 )
 
 type stopTestSuite struct {
@@ -37,13 +37,10 @@ func (s *stopTestSuite) TestStop_WhenAllServicesSelected_ThenSuccess() {
 
 	msg := "Select services to stop"
 	items := []string{"all", "nginx", "db", "cache", "wordpress"}
-	serviceContainer1 := docker.Container{Name: "nginx", Image: "nginx:alpine"}
-	serviceContainer2 := docker.Container{
-		Name:            "db",
-		Image:           "mysql:latest",
-		EnvironmentVars: []string{"MYSQL_ALLOW_EMPTY_PASSWORD=true"}}
-	serviceContainer3 := docker.Container{Name: "cache", Image: "memcached"}
-	serviceContainer4 := docker.Container{Name: "wordpress", Image: "wordpress:6.0"}
+	serviceContainer1 := nginxContainer()
+	serviceContainer2 := dbContainer()
+	serviceContainer3 := cacheContainer()
+	serviceContainer4 := wordpressContainer()
 
 	matcher := mock.MatchedBy(matchElements(items))
 	s.prompt.On("SelectPrompt", msg, matcher).Return(items[0:1], nil)
@@ -54,9 +51,33 @@ func (s *stopTestSuite) TestStop_WhenAllServicesSelected_ThenSuccess() {
 	s.client.On("ServiceDecommissioning", ctx, serviceContainer4).Return(nil).Once()
 
 	// Act
-	s.sut.Run(nil, []string{filePath})
+	err := s.sut.RunE(nil, []string{filePath})
 
 	// Assert
+	s.NoError(err)
+	s.prompt.AssertExpectations(s.T())
+	s.client.AssertExpectations(s.T())
+	s.Equal(serviceContainer4, s.client.Calls[0].Arguments.Get(1))
+}
+
+func (s *stopTestSuite) TestStop_WhenServiceWithDependenciesSelected_ThenDependenciesAreKept() {
+	// Arrange
+	ctx := context.Background()
+
+	msg := "Select services to stop"
+	items := []string{"all", "nginx", "db", "cache", "wordpress"}
+	serviceContainer := wordpressContainer()
+
+	matcher := mock.MatchedBy(matchElements(items))
+	s.prompt.On("SelectPrompt", msg, matcher).Return(items[4:5], nil)
+
+	s.client.On("ServiceDecommissioning", ctx, serviceContainer).Return(nil).Once()
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.NoError(err)
 	s.prompt.AssertExpectations(s.T())
 	s.client.AssertExpectations(s.T())
 }
@@ -67,7 +88,7 @@ func (s *stopTestSuite) TestStop_WhenSingleServiceSelected_ThenSuccess() {
 
 	msg := "Select services to stop"
 	items := []string{"all", "nginx", "db", "cache", "wordpress"}
-	serviceContainer := docker.Container{Name: "nginx", Image: "nginx:alpine"}
+	serviceContainer := nginxContainer()
 
 	matcher := mock.MatchedBy(matchElements(items))
 	s.prompt.On("SelectPrompt", msg, matcher).Return(items[1:2], nil)
@@ -75,9 +96,30 @@ func (s *stopTestSuite) TestStop_WhenSingleServiceSelected_ThenSuccess() {
 	s.client.On("ServiceDecommissioning", ctx, serviceContainer).Return(nil)
 
 	// Act
-	s.sut.Run(nil, []string{filePath})
+	err := s.sut.RunE(nil, []string{filePath})
 
 	// Assert
+	s.NoError(err)
+	s.prompt.AssertExpectations(s.T())
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *stopTestSuite) TestStop_WhenAllFlagIsSet_ThenPromptIsSkipped() {
+	// Arrange
+	ctx := context.Background()
+
+	s.client.On("ServiceDecommissioning", ctx, nginxContainer()).Return(nil).Once()
+	s.client.On("ServiceDecommissioning", ctx, dbContainer()).Return(nil).Once()
+	s.client.On("ServiceDecommissioning", ctx, cacheContainer()).Return(nil).Once()
+	s.client.On("ServiceDecommissioning", ctx, wordpressContainer()).Return(nil).Once()
+
+	s.Require().NoError(s.sut.Flags().Set("all", "true"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.NoError(err)
 	s.prompt.AssertExpectations(s.T())
 	s.client.AssertExpectations(s.T())
 }
@@ -86,9 +128,10 @@ func (s *stopTestSuite) TestStop_WhenErrorOccursOnParsing_ThenFailure() {
 	// Arrange
 
 	// Act
-	s.sut.Run(nil, []string{""})
+	err := s.sut.RunE(nil, []string{""})
 
 	// Assert
+	s.Error(err)
 	s.prompt.AssertExpectations(s.T())
 	s.client.AssertExpectations(s.T())
 }
@@ -102,9 +145,10 @@ func (s *stopTestSuite) TestStop_WhenErrorOccursOnSelectingServices_ThenFailure(
 	s.prompt.On("SelectPrompt", msg, matcher).Return(nil, errors.New("error"))
 
 	// Act
-	s.sut.Run(nil, []string{})
+	err := s.sut.RunE(nil, []string{filePath})
 
 	// Assert
+	s.Error(err)
 	s.prompt.AssertExpectations(s.T())
 	s.client.AssertExpectations(s.T())
 }
@@ -115,7 +159,7 @@ func (s *stopTestSuite) TestStop_WhenErrorOccursOnServiceProvisioning_ThenFailur
 
 	msg := "Select services to stop"
 	items := []string{"all", "nginx", "db", "cache", "wordpress"}
-	serviceContainer := docker.Container{Name: "nginx", Image: "nginx:alpine"}
+	serviceContainer := nginxContainer()
 
 	matcher := mock.MatchedBy(matchElements(items))
 	s.prompt.On("SelectPrompt", msg, matcher).Return(items[1:2], nil)
@@ -123,9 +167,38 @@ func (s *stopTestSuite) TestStop_WhenErrorOccursOnServiceProvisioning_ThenFailur
 	s.client.On("ServiceDecommissioning", ctx, serviceContainer).Return(errors.New("error"))
 
 	// Act
-	s.sut.Run(nil, []string{})
+	err := s.sut.RunE(nil, []string{filePath})
 
 	// Assert
+	s.Error(err)
 	s.prompt.AssertExpectations(s.T())
 	s.client.AssertExpectations(s.T())
 }
+
+func (s *stopTestSuite) TestStop_WhenNotInTerminalWithoutSelectionFlags_ThenFailure() {
+	// Arrange
+	msg := "Select services to stop"
+	items := []string{"all", "cache", "db", "nginx", "wordpress"}
+
+	s.prompt.On("SelectPrompt", msg, items).Return(nil, prompt.ErrNotTerminal)
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.ErrorIs(err, prompt.ErrNotTerminal)
+	s.prompt.AssertExpectations(s.T())
+	s.client.AssertNotCalled(s.T(), "ServiceDecommissioning")
+}
+
+func (s *stopTestSuite) TestStop_WhenSelectedServiceIsUndefined_ThenFailure() {
+	// Arrange
+	s.Require().NoError(s.sut.Flags().Set("service", "unknown"))
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.Error(err)
+	s.client.AssertNotCalled(s.T(), "ServiceDecommissioning")
+}
@@ -0,0 +1,47 @@
+package command
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/petrovskiborislav/docker-cli/logger"
+	"github.com/petrovskiborislav/docker-cli/yaml"
+)
+
+// NewValidateCommand creates validate command which checks the compose
+// files and fails when they contain any problem.
+func NewValidateCommand(logger logger.Logger) *cobra.Command {
+	compose := composeOptions{logger: logger}
+
+	cmd := &cobra.Command{
+		Use:           "validate [PATH to docker-compose file]",
+		Short:         "Validates the specified compose file and reports all its problems",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	cmd.RunE = func(_ *cobra.Command, args []string) error {
+		composeFile, err := compose.parseComposeFile(args)
+		if err != nil {
+			var validationErrs yaml.ValidationErrors
+			if !errors.As(err, &validationErrs) {
+				logger.Error("Error parsing compose file: %s\n", err)
+				return err
+			}
+
+			for _, validationErr := range validationErrs {
+				logger.Error("%s\n", validationErr)
+			}
+			return err
+		}
+
+		logger.Info("Compose file %s is valid\n", strings.Join(composeFile.ConfigFiles, ", "))
+		return nil
+	}
+
+	addComposeFlags(cmd, &compose)
+
+	return cmd
+}
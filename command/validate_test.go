@@ -0,0 +1,62 @@
+package command_test
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/petrovskiborislav/docker-cli/command"
+	"github.com/petrovskiborislav/docker-cli/logger"
+	"github.com/petrovskiborislav/docker-cli/yaml"
+)
+
+type validateTestSuite struct {
+	suite.Suite
+	sut *cobra.Command
+}
+
+func (s *validateTestSuite) SetupTest() {
+	s.sut = command.NewValidateCommand(logger.NewLogger())
+}
+
+func TestSuite_Validate(t *testing.T) {
+	suite.Run(t, &validateTestSuite{})
+}
+
+func (s *validateTestSuite) TestValidate_WhenComposeFileIsValid_ThenSuccess() {
+	// Arrange
+
+	// Act
+	err := s.sut.RunE(nil, []string{filePath})
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *validateTestSuite) TestValidate_WhenComposeFileIsInvalid_ThenAllErrorsAreReturned() {
+	// Arrange
+	invalidPath := "testdata/invalid-compose.yaml"
+
+	// Act
+	err := s.sut.RunE(nil, []string{invalidPath})
+
+	// Assert
+	var validationErrs yaml.ValidationErrors
+	s.Require().ErrorAs(err, &validationErrs)
+	s.Equal(yaml.ValidationErrors{
+		{File: invalidPath, Line: 4, Column: 5, Message: `unknown key "enviroment" in services.web`},
+		{File: invalidPath, Line: 6, Column: 3, Message: "service worker must define an image or a build"},
+		{File: invalidPath, Line: 8, Column: 9, Message: `services.worker.ports: invalid port "80:abc": Invalid containerPort: abc`},
+	}, validationErrs)
+}
+
+func (s *validateTestSuite) TestValidate_WhenComposeFileIsMissing_ThenFailure() {
+	// Arrange
+
+	// Act
+	err := s.sut.RunE(nil, []string{"missing-compose.yaml"})
+
+	// Assert
+	s.Error(err)
+}
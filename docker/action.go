@@ -4,28 +4,52 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os"
+	"math"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/strslice"
+	volumeTypes "github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
+	"github.com/docker/go-units"
 
 	dockerClient "github.com/docker/docker/client"
 )
 
+const defaultPortProtocol = "tcp"
+
 //go:generate mockery --name=Actions --structname mockActions --filename mock_actions_test.go --outpkg=docker_test --output=.
 
 // Actions represents a set of actions that can be performed on docker engine.
 // This interface is used to mock docker SDK in tests.
 type Actions interface {
 	CheckIfImageExists(ctx context.Context, imageName string) (bool, error)
-	PullImage(ctx context.Context, imageName string) error
-	CreateNetwork(ctx context.Context, networkName string) (string, error)
-	CreateContainerWithNetwork(ctx context.Context, imageName, containerName, networkID string, envs []string) (string, error)
+	PullImage(ctx context.Context, imageName string) (io.ReadCloser, error)
+	BuildImage(ctx context.Context, imageName string, build Build) (io.ReadCloser, error)
+	InspectImage(ctx context.Context, imageName string) (string, error)
+	CreateNetwork(ctx context.Context, network Network) (string, error)
+	InspectNetwork(ctx context.Context, networkName string) (types.NetworkResource, error)
+	CreateVolume(ctx context.Context, volume Volume) error
+	CreateContainerWithNetwork(ctx context.Context, container Container) (string, error)
 	StartContainer(ctx context.Context, containerID string) error
-	StopContainer(ctx context.Context, containerName string) (string, error)
+	InspectContainer(ctx context.Context, containerName string) (types.ContainerJSON, error)
+	ListContainers(ctx context.Context, containerNames []string, all bool) ([]types.Container, error)
+	ContainerLogs(ctx context.Context, containerName string, options LogOptions) (io.ReadCloser, error)
+	CreateExec(ctx context.Context, containerName string, options ExecOptions) (string, error)
+	AttachExec(ctx context.Context, execID string, tty bool) (types.HijackedResponse, error)
+	ResizeExec(ctx context.Context, execID string, size TerminalSize) error
+	InspectExec(ctx context.Context, execID string) (int, error)
+	StopContainer(ctx context.Context, container Container) (string, error)
+	RestartContainer(ctx context.Context, containerID string, timeout time.Duration) error
 	RemoveContainer(ctx context.Context, containerID string) error
-	RemoveNetwork(ctx context.Context, containerName string) error
+	RemoveNetwork(ctx context.Context, network Network) error
 }
 
 type actions struct {
@@ -51,40 +75,164 @@ func (a actions) CheckIfImageExists(ctx context.Context, imageName string) (bool
 	return len(images) == 1, nil
 }
 
-// PullImage pulls an image from the docker hub.
-func (a actions) PullImage(ctx context.Context, imageName string) error {
-	reader, err := a.client.ImagePull(ctx, imageName, types.ImagePullOptions{})
+// PullImage pulls an image from the docker hub. The returned reader streams the JSON messages
+// reporting the progress of the pull, which completes once they are all read.
+func (a actions) PullImage(ctx context.Context, imageName string) (io.ReadCloser, error) {
+	return a.client.ImagePull(ctx, imageName, types.ImagePullOptions{})
+}
+
+// BuildImage builds an image from the build context and tags it with the image name. The context is
+// sent without the paths excluded by its .dockerignore file and the returned reader streams the JSON
+// messages reporting the progress of the build.
+func (a actions) BuildImage(ctx context.Context, imageName string, build Build) (io.ReadCloser, error) {
+	dockerfile := build.Dockerfile
+	if dockerfile == "" {
+		dockerfile = defaultDockerfile
+	}
+	dockerfile, err := contextDockerfile(build.Context, dockerfile)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	buildContext, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(writeBuildContext(writer, build.Context, dockerfile))
+	}()
+
+	options := types.ImageBuildOptions{
+		Tags:       []string{imageName},
+		Dockerfile: dockerfile,
+		BuildArgs:  toBuildArgs(build.Args),
+		Target:     build.Target,
+		Labels:     build.Labels,
+		CacheFrom:  build.CacheFrom,
+		Remove:     true,
+	}
+
+	response, err := a.client.ImageBuild(ctx, buildContext, options)
+	if err != nil {
+		// Stops writing the context when the daemon did not read all of it.
+		buildContext.CloseWithError(err)
+		return nil, err
 	}
-	defer reader.Close()
-	io.Copy(os.Stdout, reader)
 
-	return nil
+	return response.Body, nil
+}
+
+// InspectImage returns the ID of the local image.
+func (a actions) InspectImage(ctx context.Context, imageName string) (string, error) {
+	image, _, err := a.client.ImageInspectWithRaw(ctx, imageName)
+	if err != nil {
+		return "", err
+	}
+
+	return image.ID, nil
 }
 
 // CreateNetwork creates a new network.
-func (a actions) CreateNetwork(ctx context.Context, networkName string) (string, error) {
-	network, err := a.client.NetworkCreate(ctx, networkName, types.NetworkCreate{})
+func (a actions) CreateNetwork(ctx context.Context, network Network) (string, error) {
+	networkCreate := types.NetworkCreate{
+		Driver:     network.Driver,
+		Options:    network.DriverOpts,
+		Labels:     withProjectLabel(network.Labels, network.Project),
+		Internal:   network.Internal,
+		Attachable: network.Attachable,
+	}
+
+	createdNetwork, err := a.client.NetworkCreate(ctx, network.Name, networkCreate)
 	if err != nil {
 		return "", err
 	}
-	return network.ID, nil
+	return createdNetwork.ID, nil
+}
+
+// InspectNetwork returns the information of a network including the containers connected to it.
+func (a actions) InspectNetwork(ctx context.Context, networkName string) (types.NetworkResource, error) {
+	return a.client.NetworkInspect(ctx, networkName, types.NetworkInspectOptions{})
 }
 
-// CreateContainerWithNetwork creates a new container and connects it to the specified network.
-func (a actions) CreateContainerWithNetwork(ctx context.Context, imageName, containerName, networkID string, env []string) (string, error) {
-	containerConfig := &container.Config{Image: imageName, Env: env}
-	createdContainer, err := a.client.ContainerCreate(ctx, containerConfig, nil, nil, nil, containerName)
+// CreateVolume creates a new named volume. Creating an already existing volume is a no-op.
+func (a actions) CreateVolume(ctx context.Context, volume Volume) error {
+	volumeCreateBody := volumeTypes.VolumeCreateBody{
+		Name:       volume.Name,
+		Driver:     volume.Driver,
+		DriverOpts: volume.DriverOpts,
+		Labels:     withProjectLabel(volume.Labels, volume.Project),
+	}
+
+	_, err := a.client.VolumeCreate(ctx, volumeCreateBody)
+	return err
+}
+
+// CreateContainerWithNetwork creates a new container and connects it to its networks.
+func (a actions) CreateContainerWithNetwork(ctx context.Context, serviceContainer Container) (string, error) {
+	exposedPorts, portBindings, err := toPortBindings(serviceContainer.Ports)
 	if err != nil {
 		return "", err
 	}
 
-	err = a.client.NetworkConnect(ctx, networkID, createdContainer.ID, nil)
+	containerConfig := &container.Config{
+		Image:        serviceContainer.Image,
+		Cmd:          strslice.StrSlice(serviceContainer.Command),
+		Entrypoint:   strslice.StrSlice(serviceContainer.Entrypoint),
+		WorkingDir:   serviceContainer.WorkingDir,
+		User:         serviceContainer.User,
+		Hostname:     serviceContainer.Hostname,
+		Tty:          serviceContainer.Tty,
+		OpenStdin:    serviceContainer.StdinOpen,
+		Env:          serviceContainer.EnvironmentVars,
+		ExposedPorts: exposedPorts,
+		Healthcheck:  toHealthConfig(serviceContainer.Healthcheck),
+		Labels:       withComposeLabels(serviceContainer),
+		Domainname:   serviceContainer.Domainname,
+		StopSignal:   serviceContainer.StopSignal,
+		StopTimeout:  toStopTimeout(serviceContainer.StopGracePeriod),
+	}
+	hostConfig := &container.HostConfig{
+		PortBindings:   portBindings,
+		Mounts:         toMounts(serviceContainer.Mounts),
+		Privileged:     serviceContainer.Privileged,
+		CapAdd:         strslice.StrSlice(serviceContainer.CapAdd),
+		CapDrop:        strslice.StrSlice(serviceContainer.CapDrop),
+		ReadonlyRootfs: serviceContainer.ReadOnly,
+		SecurityOpt:    serviceContainer.SecurityOpt,
+		Tmpfs:          serviceContainer.Tmpfs,
+		Sysctls:        serviceContainer.Sysctls,
+		ExtraHosts:     serviceContainer.ExtraHosts,
+		DNS:            serviceContainer.DNS,
+		DNSSearch:      serviceContainer.DNSSearch,
+		LogConfig:      toLogConfig(serviceContainer.Logging),
+	}
+	applyRestartPolicy(hostConfig, serviceContainer.RestartPolicy)
+	applyResources(hostConfig, serviceContainer.Resources)
+	hostConfig.Ulimits = toUlimits(serviceContainer.Ulimits)
+
+	// The container can only be created with a single network, the remaining ones are connected afterwards.
+	var networkingConfig *network.NetworkingConfig
+	if len(serviceContainer.Networks) > 0 {
+		primaryNetwork := serviceContainer.Networks[0]
+		hostConfig.NetworkMode = container.NetworkMode(primaryNetwork.Name)
+		networkingConfig = &network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{
+				primaryNetwork.Name: {Aliases: primaryNetwork.Aliases},
+			},
+		}
+	}
+
+	createdContainer, err := a.client.ContainerCreate(ctx, containerConfig, hostConfig, networkingConfig, nil, serviceContainer.Name)
 	if err != nil {
 		return "", err
 	}
 
+	for i := 1; i < len(serviceContainer.Networks); i++ {
+		containerNetwork := serviceContainer.Networks[i]
+		endpointSettings := &network.EndpointSettings{Aliases: containerNetwork.Aliases}
+		err = a.client.NetworkConnect(ctx, containerNetwork.Name, createdContainer.ID, endpointSettings)
+		if err != nil {
+			return "", err
+		}
+	}
+
 	return createdContainer.ID, nil
 }
 
@@ -93,24 +241,135 @@ func (a actions) StartContainer(ctx context.Context, containerID string) error {
 	return a.client.ContainerStart(ctx, containerID, types.ContainerStartOptions{})
 }
 
-// StopContainer stops a container.
-func (a actions) StopContainer(ctx context.Context, containerName string) (string, error) {
+// InspectContainer returns the low-level information of a container.
+func (a actions) InspectContainer(ctx context.Context, containerName string) (types.ContainerJSON, error) {
+	return a.client.ContainerInspect(ctx, containerName)
+}
+
+// ListContainers lists the containers with the given names. Stopped containers are only included when all is set.
+func (a actions) ListContainers(ctx context.Context, containerNames []string, all bool) ([]types.Container, error) {
+	if len(containerNames) == 0 {
+		return nil, nil
+	}
+
+	filter := filters.NewArgs()
+	for _, containerName := range containerNames {
+		filter.Add("name", exactNamePattern("/"+containerName))
+	}
+
+	containerListOptions := types.ContainerListOptions{All: all, Filters: filter}
+	containers, err := a.client.ContainerList(ctx, containerListOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return containersNamed(containers, containerNames), nil
+}
+
+// ContainerLogs returns the stdout and stderr logs of a container. The streams are multiplexed
+// unless the container has a TTY attached.
+func (a actions) ContainerLogs(ctx context.Context, containerName string, options LogOptions) (io.ReadCloser, error) {
+	containerLogsOptions := types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     options.Follow,
+		Tail:       options.Tail,
+		Since:      options.Since,
+		Timestamps: options.Timestamps,
+	}
+
+	return a.client.ContainerLogs(ctx, containerName, containerLogsOptions)
+}
+
+// CreateExec creates a command to be run inside a running container.
+func (a actions) CreateExec(ctx context.Context, containerName string, options ExecOptions) (string, error) {
+	execConfig := types.ExecConfig{
+		User:         options.User,
+		Tty:          options.Tty,
+		AttachStdin:  options.Interactive,
+		AttachStdout: true,
+		AttachStderr: true,
+		Env:          options.Env,
+		WorkingDir:   options.WorkingDir,
+		Cmd:          options.Command,
+	}
+
+	response, err := a.client.ContainerExecCreate(ctx, containerName, execConfig)
+	if err != nil {
+		return "", err
+	}
+
+	return response.ID, nil
+}
+
+// AttachExec starts a created command and attaches to its standard streams.
+func (a actions) AttachExec(ctx context.Context, execID string, tty bool) (types.HijackedResponse, error) {
+	return a.client.ContainerExecAttach(ctx, execID, types.ExecStartCheck{Tty: tty})
+}
+
+// ResizeExec resizes the terminal of a command started with a TTY.
+func (a actions) ResizeExec(ctx context.Context, execID string, size TerminalSize) error {
+	return a.client.ContainerExecResize(ctx, execID, types.ResizeOptions{Height: size.Height, Width: size.Width})
+}
+
+// InspectExec returns the exit code of a command.
+func (a actions) InspectExec(ctx context.Context, execID string) (int, error) {
+	execInspect, err := a.client.ContainerExecInspect(ctx, execID)
+	if err != nil {
+		return 0, err
+	}
+
+	return execInspect.ExitCode, nil
+}
+
+// StopContainer stops the container created for the service of the container within its project and
+// returns its ID, a container which is not running is only looked up. Only the container owned by the
+// project and named exactly as the container is considered.
+func (a actions) StopContainer(ctx context.Context, serviceContainer Container) (string, error) {
 	filter := filters.NewArgs()
-	filter.Add("name", containerName)
+	filter.Add("label", LabelProject+"="+serviceContainer.Project)
+	filter.Add("label", LabelService+"="+serviceContainer.Service)
+	filter.Add("name", exactNamePattern("/"+serviceContainer.Name))
 
-	containerListOptions := types.ContainerListOptions{Filters: filter}
+	// Exited containers are listed too so that they are removed as well.
+	containerListOptions := types.ContainerListOptions{All: true, Filters: filter}
 	containers, err := a.client.ContainerList(ctx, containerListOptions)
 	if err != nil {
 		return "", err
 	}
 
-	if len(containers) == 0 {
+	matches := containersNamed(containers, []string{serviceContainer.Name})
+	switch len(matches) {
+	case 0:
 		return "", nil
+	case 1:
+		containerID := matches[0].ID
+		if !isRunningState(matches[0].State) {
+			return containerID, nil
+		}
+
+		var timeout *time.Duration
+		if serviceContainer.StopGracePeriod > 0 {
+			timeout = &serviceContainer.StopGracePeriod
+		}
+		return containerID, a.client.ContainerStop(ctx, containerID, timeout)
+	default:
+		descriptions := make([]string, 0, len(matches))
+		for _, c := range matches {
+			descriptions = append(descriptions, fmt.Sprintf("%s (%s)", serviceContainer.Name, c.ID))
+		}
+		return "", fmt.Errorf("multiple containers match %s: %s", serviceContainer.Name, strings.Join(descriptions, ", "))
 	}
+}
 
-	containerID := containers[0].ID
+// isRunningState reports whether a container in the state has a process which has to be stopped.
+func isRunningState(state string) bool {
+	return state != containerStatusCreated && state != containerStatusExited && state != containerStatusDead
+}
 
-	return containerID, a.client.ContainerStop(ctx, containerID, nil)
+// RestartContainer stops a container, killing it once the timeout expires, and starts it again.
+func (a actions) RestartContainer(ctx context.Context, containerID string, timeout time.Duration) error {
+	return a.client.ContainerRestart(ctx, containerID, &timeout)
 }
 
 // RemoveContainer removes a container.
@@ -118,10 +377,11 @@ func (a actions) RemoveContainer(ctx context.Context, containerID string) error
 	return a.client.ContainerRemove(ctx, containerID, types.ContainerRemoveOptions{})
 }
 
-// RemoveNetwork removes a network.
-func (a actions) RemoveNetwork(ctx context.Context, containerName string) error {
+// RemoveNetwork removes the network owned by the project and named exactly as the network.
+func (a actions) RemoveNetwork(ctx context.Context, network Network) error {
 	filter := filters.NewArgs()
-	filter.Add("name", fmt.Sprintf("%s-network", containerName))
+	filter.Add("name", exactNamePattern(network.Name))
+	filter.Add("label", LabelProject+"="+network.Project)
 
 	networkListOptions := types.NetworkListOptions{Filters: filter}
 	networks, err := a.client.NetworkList(ctx, networkListOptions)
@@ -129,5 +389,210 @@ func (a actions) RemoveNetwork(ctx context.Context, containerName string) error
 		return err
 	}
 
-	return a.client.NetworkRemove(ctx, networks[0].ID)
+	var matches []types.NetworkResource
+	var descriptions []string
+	for _, n := range networks {
+		if n.Name == network.Name {
+			matches = append(matches, n)
+			descriptions = append(descriptions, fmt.Sprintf("%s (%s)", n.Name, n.ID))
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return errdefs.NotFound(fmt.Errorf("network %s not found", network.Name))
+	case 1:
+		return a.client.NetworkRemove(ctx, matches[0].ID)
+	default:
+		return fmt.Errorf("multiple networks match %s: %s", network.Name, strings.Join(descriptions, ", "))
+	}
+}
+
+// exactNamePattern returns the name filter matching only the given name.
+func exactNamePattern(name string) string {
+	return "^" + regexp.QuoteMeta(name) + "$"
+}
+
+// containersNamed returns the containers named exactly as one of the names. The name filter is a
+// regular expression evaluated by the daemon, so the names of the listed containers are compared once more.
+func containersNamed(containers []types.Container, containerNames []string) []types.Container {
+	wanted := make(map[string]bool, len(containerNames))
+	for _, containerName := range containerNames {
+		wanted["/"+containerName] = true
+	}
+
+	var result []types.Container
+	for _, c := range containers {
+		for _, name := range c.Names {
+			if wanted[name] {
+				result = append(result, c)
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// withProjectLabel returns a copy of the labels including the project label.
+func withProjectLabel(labels map[string]string, project string) map[string]string {
+	if project == "" {
+		return labels
+	}
+
+	projectLabels := make(map[string]string, len(labels)+1)
+	for key, value := range labels {
+		projectLabels[key] = value
+	}
+	projectLabels[LabelProject] = project
+
+	return projectLabels
+}
+
+// withComposeLabels returns the labels of the container together with the compose labels, which take precedence.
+func withComposeLabels(serviceContainer Container) map[string]string {
+	composeLabels := serviceContainer.ComposeLabels()
+	labels := make(map[string]string, len(serviceContainer.Labels)+len(composeLabels))
+	for key, value := range serviceContainer.Labels {
+		labels[key] = value
+	}
+	for key, value := range composeLabels {
+		labels[key] = value
+	}
+
+	return labels
+}
+
+// toStopTimeout returns the stop grace period in whole seconds as expected by the daemon.
+func toStopTimeout(stopGracePeriod time.Duration) *int {
+	if stopGracePeriod <= 0 {
+		return nil
+	}
+
+	seconds := int(math.Ceil(stopGracePeriod.Seconds()))
+	return &seconds
+}
+
+func toLogConfig(logging *Logging) container.LogConfig {
+	if logging == nil {
+		return container.LogConfig{}
+	}
+
+	return container.LogConfig{Type: logging.Driver, Config: logging.Options}
+}
+
+func toPortBindings(ports []Port) (nat.PortSet, nat.PortMap, error) {
+	if len(ports) == 0 {
+		return nil, nil, nil
+	}
+
+	exposedPorts := nat.PortSet{}
+	portBindings := nat.PortMap{}
+	for _, port := range ports {
+		protocol := port.Protocol
+		if protocol == "" {
+			protocol = defaultPortProtocol
+		}
+
+		containerPort, err := nat.NewPort(protocol, port.ContainerPort)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		exposedPorts[containerPort] = struct{}{}
+		portBindings[containerPort] = append(portBindings[containerPort], nat.PortBinding{
+			HostIP:   port.HostIP,
+			HostPort: port.HostPort,
+		})
+	}
+
+	return exposedPorts, portBindings, nil
+}
+
+func toMounts(mounts []Mount) []mount.Mount {
+	var result []mount.Mount
+	for _, m := range mounts {
+		dockerMount := mount.Mount{
+			Type:     mount.Type(m.Type),
+			Source:   m.Source,
+			Target:   m.Target,
+			ReadOnly: m.ReadOnly,
+		}
+
+		if m.Propagation != "" {
+			dockerMount.BindOptions = &mount.BindOptions{Propagation: mount.Propagation(m.Propagation)}
+		}
+
+		if m.NoCopy {
+			dockerMount.VolumeOptions = &mount.VolumeOptions{NoCopy: true}
+		}
+
+		result = append(result, dockerMount)
+	}
+
+	return result
+}
+
+func applyRestartPolicy(hostConfig *container.HostConfig, restartPolicy *RestartPolicy) {
+	if restartPolicy == nil {
+		return
+	}
+
+	hostConfig.RestartPolicy = container.RestartPolicy{
+		Name:              restartPolicy.Name,
+		MaximumRetryCount: restartPolicy.MaximumRetryCount,
+	}
+}
+
+func applyResources(hostConfig *container.HostConfig, resources *Resources) {
+	if resources == nil {
+		return
+	}
+
+	hostConfig.Memory = resources.Memory
+	hostConfig.MemoryReservation = resources.MemoryReservation
+	hostConfig.NanoCPUs = resources.NanoCPUs
+	hostConfig.CPUShares = resources.CPUShares
+	hostConfig.ShmSize = resources.ShmSize
+	if resources.PidsLimit != 0 {
+		pidsLimit := resources.PidsLimit
+		hostConfig.PidsLimit = &pidsLimit
+	}
+}
+
+func toUlimits(ulimits []Ulimit) []*units.Ulimit {
+	var dockerUlimits []*units.Ulimit
+	for _, ulimit := range ulimits {
+		dockerUlimits = append(dockerUlimits, &units.Ulimit{Name: ulimit.Name, Soft: ulimit.Soft, Hard: ulimit.Hard})
+	}
+
+	return dockerUlimits
+}
+
+func toHealthConfig(healthcheck *Healthcheck) *container.HealthConfig {
+	if healthcheck == nil {
+		return nil
+	}
+
+	return &container.HealthConfig{
+		Test:        healthcheck.Test,
+		Interval:    healthcheck.Interval,
+		Timeout:     healthcheck.Timeout,
+		StartPeriod: healthcheck.StartPeriod,
+		Retries:     healthcheck.Retries,
+	}
+}
+
+func toBuildArgs(args map[string]string) map[string]*string {
+	if len(args) == 0 {
+		return nil
+	}
+
+	buildArgs := make(map[string]*string, len(args))
+	for key, value := range args {
+		value := value
+		buildArgs[key] = &value
+	}
+
+	return buildArgs
 }
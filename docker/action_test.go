@@ -1,16 +1,28 @@
 package docker_test
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
 	"errors"
-	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/strslice"
+	volumeTypes "github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
+	"github.com/docker/go-units"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
@@ -27,7 +39,22 @@ type actionsTestSuite struct {
 
 func (s *actionsTestSuite) SetupTest() {
 	s.client = &mockAPIClient{}
-	s.sut = docker.NewActions(s.client)
+	s.sut = docker.NewActions(bufferedBuildClient{s.client})
+}
+
+// bufferedBuildClient reads the whole build context before recording the call. The context is written
+// by another goroutine and the mock would otherwise format the pipe while it is being written.
+type bufferedBuildClient struct {
+	*mockAPIClient
+}
+
+func (c bufferedBuildClient) ImageBuild(ctx context.Context, buildContext io.Reader, options types.ImageBuildOptions) (types.ImageBuildResponse, error) {
+	archive, err := io.ReadAll(buildContext)
+	if err != nil {
+		return types.ImageBuildResponse{}, err
+	}
+
+	return c.mockAPIClient.ImageBuild(ctx, bytes.NewReader(archive), options)
 }
 
 func (s *actionsTestSuite) AfterTest(suiteName string, testName string) {
@@ -99,15 +126,16 @@ func (s *actionsTestSuite) TestPullImage_ThenSuccess() {
 	// Arrange
 	ctx := context.Background()
 	image := "image"
-	reader := io.NopCloser(strings.NewReader("Success"))
+	progress := io.NopCloser(strings.NewReader(`{"status":"Pulling from library/image"}`))
 
-	s.client.On("ImagePull", ctx, image, types.ImagePullOptions{}).Return(reader, nil)
+	s.client.On("ImagePull", ctx, image, types.ImagePullOptions{}).Return(progress, nil)
 
 	// Act
-	err := s.sut.PullImage(ctx, image)
+	reader, err := s.sut.PullImage(ctx, image)
 
 	// Assert
 	s.NoError(err)
+	s.Equal(progress, reader)
 }
 
 func (s *actionsTestSuite) TestPullImage_ThenFailure() {
@@ -118,59 +146,676 @@ func (s *actionsTestSuite) TestPullImage_ThenFailure() {
 	s.client.On("ImagePull", ctx, image, types.ImagePullOptions{}).Return(nil, errors.New("error"))
 
 	// Act
-	err := s.sut.PullImage(ctx, image)
+	reader, err := s.sut.PullImage(ctx, image)
+
+	// Assert
+	s.Error(err)
+	s.Nil(reader)
+}
+
+func (s *actionsTestSuite) TestBuildImage_ThenIgnoredPathsAreNotSent() {
+	// Arrange
+	ctx := context.Background()
+	contextDir := s.T().TempDir()
+	files := map[string]string{
+		".dockerignore":         "# local files\n*.log\nnode_modules\n!node_modules/keep.js\nDockerfile.dev\n",
+		"Dockerfile.dev":        "FROM alpine",
+		"main.go":               "package main",
+		"debug.log":             "debug",
+		"node_modules/lib.js":   "lib",
+		"node_modules/keep.js":  "keep",
+		"cmd/server/server.log": "server",
+	}
+	for name, content := range files {
+		path := filepath.Join(contextDir, name)
+		s.Require().NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+		s.Require().NoError(os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	build := docker.Build{
+		Context:    contextDir,
+		Dockerfile: "Dockerfile.dev",
+		Args:       map[string]string{"GO_VERSION": "1.18"},
+		Target:     "release",
+		Labels:     map[string]string{"team": "web"},
+		CacheFrom:  []string{"web:cache"},
+	}
+	goVersion := "1.18"
+	options := types.ImageBuildOptions{
+		Tags:       []string{"project-web"},
+		Dockerfile: "Dockerfile.dev",
+		BuildArgs:  map[string]*string{"GO_VERSION": &goVersion},
+		Target:     "release",
+		Labels:     map[string]string{"team": "web"},
+		CacheFrom:  []string{"web:cache"},
+		Remove:     true,
+	}
+
+	var sent []string
+	body := io.NopCloser(strings.NewReader(`{"stream":"Successfully built"}`))
+	s.client.On("ImageBuild", ctx, mock.AnythingOfType("*bytes.Reader"), options).
+		Run(func(args mock.Arguments) {
+			archive := tar.NewReader(args.Get(1).(io.Reader))
+			for {
+				header, err := archive.Next()
+				if err != nil {
+					break
+				}
+				sent = append(sent, header.Name)
+			}
+		}).
+		Return(types.ImageBuildResponse{Body: body}, nil)
+
+	// Act
+	reader, err := s.sut.BuildImage(ctx, "project-web", build)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(body, reader)
+	s.ElementsMatch([]string{".dockerignore", "Dockerfile.dev", "cmd/", "cmd/server/", "cmd/server/server.log", "main.go", "node_modules/keep.js"}, sent)
+}
+
+func (s *actionsTestSuite) TestBuildImage_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	build := docker.Build{Context: s.T().TempDir()}
+	options := types.ImageBuildOptions{Tags: []string{"project-web"}, Dockerfile: "Dockerfile", Remove: true}
+
+	s.client.On("ImageBuild", ctx, mock.AnythingOfType("*bytes.Reader"), options).Return(types.ImageBuildResponse{}, errors.New("error"))
+
+	// Act
+	reader, err := s.sut.BuildImage(ctx, "project-web", build)
+
+	// Assert
+	s.Error(err)
+	s.Nil(reader)
+}
+
+func (s *actionsTestSuite) TestBuildImage_WhenDockerfileIsOutsideContext_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	contextDir := s.T().TempDir()
+	build := docker.Build{Context: filepath.Join(contextDir, "api"), Dockerfile: "../Dockerfile"}
+
+	// Act
+	reader, err := s.sut.BuildImage(ctx, "project-api", build)
 
 	// Assert
 	s.Error(err)
+	s.Contains(err.Error(), "dockerfile ../Dockerfile is outside of the build context")
+	s.Nil(reader)
+	s.client.AssertNotCalled(s.T(), "ImageBuild", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *actionsTestSuite) TestBuildImage_WhenDockerignorePatternIsInvalid_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	contextDir := s.T().TempDir()
+	s.Require().NoError(os.WriteFile(filepath.Join(contextDir, ".dockerignore"), []byte("!"), 0o644))
+
+	// Act
+	reader, err := s.sut.BuildImage(ctx, "project-web", docker.Build{Context: contextDir})
+
+	// Assert
+	s.Error(err)
+	s.Contains(err.Error(), "invalid .dockerignore pattern")
+	s.Nil(reader)
+}
+
+func TestBuildImage_WhenDockerignoreMatches_ThenPathsAreNotSent(t *testing.T) {
+	tests := []struct {
+		name         string
+		dockerignore string
+		files        []string
+		sent         []string
+	}{
+		{
+			name:         "double star matches any number of directories",
+			dockerignore: "**/*.tmp",
+			files:        []string{"a.tmp", "x/b.tmp", "x/y/c.tmp", "x/keep.go"},
+			sent:         []string{"x/", "x/y/", "x/keep.go"},
+		},
+		{
+			name:         "single star matches within a segment",
+			dockerignore: "*/temp*",
+			files:        []string{"temp0", "a/temp1", "a/b/temp2"},
+			sent:         []string{"temp0", "a/", "a/b/", "a/b/temp2"},
+		},
+		{
+			name:         "exclusion adds paths back",
+			dockerignore: "docs\n!docs/README.md",
+			files:        []string{"docs/guide.md", "docs/README.md"},
+			sent:         []string{"docs/README.md"},
+		},
+		{
+			name:         "negated character class",
+			dockerignore: "file[^0-9].txt",
+			files:        []string{"file1.txt", "filea.txt"},
+			sent:         []string{"file1.txt"},
+		},
+		{
+			name:         "escaped characters match literally",
+			dockerignore: "\\*.txt\nname\\?",
+			files:        []string{"*.txt", "a.txt", "name?", "names"},
+			sent:         []string{"a.txt", "names"},
+		},
+		{
+			name:         "directory matches everything inside it",
+			dockerignore: "build",
+			files:        []string{"build/out/bin", "src/build/main.go"},
+			sent:         []string{"src/", "src/build/", "src/build/main.go"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			contextDir := t.TempDir()
+			files := append([]string{".dockerignore", "Dockerfile"}, test.files...)
+			for _, name := range files {
+				content := "content"
+				if name == ".dockerignore" {
+					content = test.dockerignore
+				}
+				path := filepath.Join(contextDir, name)
+				assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+				assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+			}
+
+			client := &mockAPIClient{}
+			sut := docker.NewActions(bufferedBuildClient{client})
+
+			var sent []string
+			client.On("ImageBuild", ctx, mock.AnythingOfType("*bytes.Reader"), mock.Anything).
+				Run(func(args mock.Arguments) {
+					archive := tar.NewReader(args.Get(1).(io.Reader))
+					for {
+						header, err := archive.Next()
+						if err != nil {
+							break
+						}
+						sent = append(sent, header.Name)
+					}
+				}).
+				Return(types.ImageBuildResponse{Body: io.NopCloser(strings.NewReader(""))}, nil)
+
+			// Act
+			_, err := sut.BuildImage(ctx, "project-web", docker.Build{Context: contextDir})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, append([]string{".dockerignore", "Dockerfile"}, test.sent...), sent)
+		})
+	}
+}
+
+func (s *actionsTestSuite) TestInspectImage_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+
+	s.client.On("ImageInspectWithRaw", ctx, "image").Return(types.ImageInspect{ID: "imageID"}, nil, nil)
+
+	// Act
+	imageID, err := s.sut.InspectImage(ctx, "image")
+
+	// Assert
+	s.NoError(err)
+	s.Equal("imageID", imageID)
+}
+
+func (s *actionsTestSuite) TestInspectImage_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+
+	s.client.On("ImageInspectWithRaw", ctx, "image").Return(types.ImageInspect{}, nil, errors.New("error"))
+
+	// Act
+	imageID, err := s.sut.InspectImage(ctx, "image")
+
+	// Assert
+	s.Error(err)
+	s.Empty(imageID)
 }
 
 func (s *actionsTestSuite) TestCreateNetwork_ThenSuccess() {
 	// Arrange
 	ctx := context.Background()
-	networkName := "network"
+	network := docker.Network{Name: "network", Driver: "bridge", Internal: true}
 	networkID := "id"
 
 	networkResp := types.NetworkCreateResponse{ID: "id"}
-	s.client.On("NetworkCreate", ctx, networkName, types.NetworkCreate{}).Return(networkResp, nil)
+	s.client.On("NetworkCreate", ctx, network.Name, types.NetworkCreate{Driver: "bridge", Internal: true}).Return(networkResp, nil)
 
 	// Act
-	id, err := s.sut.CreateNetwork(ctx, networkName)
+	id, err := s.sut.CreateNetwork(ctx, network)
 
 	// Assert
 	s.NoError(err)
 	s.Equal(networkID, id)
 }
 
+func (s *actionsTestSuite) TestCreateNetwork_WhenProjectIsSet_ThenProjectLabelIsAdded() {
+	// Arrange
+	ctx := context.Background()
+	network := docker.Network{Name: "project_default", Project: "project", Labels: map[string]string{"team": "web"}}
+
+	networkCreate := types.NetworkCreate{Labels: map[string]string{"team": "web", docker.LabelProject: "project"}}
+	s.client.On("NetworkCreate", ctx, network.Name, networkCreate).Return(types.NetworkCreateResponse{ID: "id"}, nil)
+
+	// Act
+	id, err := s.sut.CreateNetwork(ctx, network)
+
+	// Assert
+	s.NoError(err)
+	s.Equal("id", id)
+	s.Equal(map[string]string{"team": "web"}, network.Labels)
+}
+
 func (s *actionsTestSuite) TestCreateNetwork_ThenFailure() {
 	// Arrange
 	ctx := context.Background()
-	networkName := "network"
+	network := docker.Network{Name: "network"}
 
-	s.client.On("NetworkCreate", ctx, networkName, types.NetworkCreate{}).Return(types.NetworkCreateResponse{}, errors.New("error"))
+	s.client.On("NetworkCreate", ctx, network.Name, types.NetworkCreate{}).Return(types.NetworkCreateResponse{}, errors.New("error"))
 
 	// Act
-	id, err := s.sut.CreateNetwork(ctx, networkName)
+	id, err := s.sut.CreateNetwork(ctx, network)
 
 	// Assert
 	s.Error(err)
 	s.Equal("", id)
 }
 
+func (s *actionsTestSuite) TestInspectNetwork_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+	networkName := "network"
+
+	networkResource := types.NetworkResource{ID: "id", Name: networkName}
+	s.client.On("NetworkInspect", ctx, networkName, types.NetworkInspectOptions{}).Return(networkResource, nil)
+
+	// Act
+	result, err := s.sut.InspectNetwork(ctx, networkName)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(networkResource, result)
+}
+
+func (s *actionsTestSuite) TestInspectNetwork_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	networkName := "network"
+
+	s.client.On("NetworkInspect", ctx, networkName, types.NetworkInspectOptions{}).Return(types.NetworkResource{}, errors.New("error"))
+
+	// Act
+	_, err := s.sut.InspectNetwork(ctx, networkName)
+
+	// Assert
+	s.Error(err)
+}
+
+func (s *actionsTestSuite) TestCreateVolume_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+	volume := docker.Volume{Name: "volume", Driver: "local", DriverOpts: map[string]string{"type": "tmpfs"}}
+
+	volumeCreateBody := volumeTypes.VolumeCreateBody{Name: "volume", Driver: "local", DriverOpts: map[string]string{"type": "tmpfs"}}
+	s.client.On("VolumeCreate", ctx, volumeCreateBody).Return(types.Volume{Name: "volume"}, nil)
+
+	// Act
+	err := s.sut.CreateVolume(ctx, volume)
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *actionsTestSuite) TestCreateVolume_WhenProjectIsSet_ThenProjectLabelIsAdded() {
+	// Arrange
+	ctx := context.Background()
+	volume := docker.Volume{Name: "project_data", Project: "project"}
+
+	volumeCreateBody := volumeTypes.VolumeCreateBody{Name: "project_data", Labels: map[string]string{docker.LabelProject: "project"}}
+	s.client.On("VolumeCreate", ctx, volumeCreateBody).Return(types.Volume{Name: "project_data"}, nil)
+
+	// Act
+	err := s.sut.CreateVolume(ctx, volume)
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *actionsTestSuite) TestCreateVolume_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	volume := docker.Volume{Name: "volume"}
+
+	volumeCreateBody := volumeTypes.VolumeCreateBody{Name: "volume"}
+	s.client.On("VolumeCreate", ctx, volumeCreateBody).Return(types.Volume{}, errors.New("error"))
+
+	// Act
+	err := s.sut.CreateVolume(ctx, volume)
+
+	// Assert
+	s.Error(err)
+}
+
 func (s *actionsTestSuite) TestCreateContainerWithNetwork_ThenSuccess() {
 	// Arrange
 	ctx := context.Background()
-	imageName := "image"
-	containerName := "container"
-	networkID := "id"
+	serviceContainer := docker.Container{Name: "container", Image: "image"}
+	containerID := "id"
+
+	containerConfig := &container.Config{Image: serviceContainer.Image, Labels: serviceContainer.ComposeLabels()}
+	containerCreateCreatedBody := container.ContainerCreateCreatedBody{ID: containerID}
+	s.client.On("ContainerCreate", ctx, containerConfig, mock.Anything, mock.Anything, mock.Anything, serviceContainer.Name).Return(containerCreateCreatedBody, nil)
+
+	// Act
+	id, err := s.sut.CreateContainerWithNetwork(ctx, serviceContainer)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(containerID, id)
+}
+
+func (s *actionsTestSuite) TestCreateContainerWithNetwork_WhenMultipleNetworksAreUsed_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+	serviceContainer := docker.Container{
+		Name:  "container",
+		Image: "image",
+		Networks: []docker.Network{
+			{Name: "front", Aliases: []string{"container"}},
+			{Name: "back", Aliases: []string{"container", "api"}},
+		},
+	}
+	containerID := "id"
+
+	containerConfig := &container.Config{Image: serviceContainer.Image, Labels: serviceContainer.ComposeLabels()}
+	hostConfig := &container.HostConfig{NetworkMode: "front"}
+	networkingConfig := &network.NetworkingConfig{
+		EndpointsConfig: map[string]*network.EndpointSettings{"front": {Aliases: []string{"container"}}},
+	}
+	containerCreateCreatedBody := container.ContainerCreateCreatedBody{ID: containerID}
+	s.client.On("ContainerCreate", ctx, containerConfig, hostConfig, networkingConfig, mock.Anything, serviceContainer.Name).Return(containerCreateCreatedBody, nil)
+	s.client.On("NetworkConnect", ctx, "back", containerID, &network.EndpointSettings{Aliases: []string{"container", "api"}}).Return(nil)
+
+	// Act
+	id, err := s.sut.CreateContainerWithNetwork(ctx, serviceContainer)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(containerID, id)
+}
+
+func (s *actionsTestSuite) TestCreateContainerWithNetwork_WhenPortsArePublished_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+	serviceContainer := docker.Container{
+		Name:  "container",
+		Image: "image",
+		Ports: []docker.Port{
+			{HostPort: "8080", ContainerPort: "80"},
+			{HostIP: "127.0.0.1", HostPort: "5353", ContainerPort: "53", Protocol: "udp"},
+		},
+	}
+	containerID := "id"
+
+	containerConfig := &container.Config{
+		Image:  serviceContainer.Image,
+		Labels: serviceContainer.ComposeLabels(),
+		ExposedPorts: nat.PortSet{
+			"80/tcp": struct{}{},
+			"53/udp": struct{}{},
+		},
+	}
+	hostConfig := &container.HostConfig{
+		PortBindings: nat.PortMap{
+			"80/tcp": {{HostPort: "8080"}},
+			"53/udp": {{HostIP: "127.0.0.1", HostPort: "5353"}},
+		},
+	}
+	containerCreateCreatedBody := container.ContainerCreateCreatedBody{ID: containerID}
+	s.client.On("ContainerCreate", ctx, containerConfig, hostConfig, mock.Anything, mock.Anything, serviceContainer.Name).Return(containerCreateCreatedBody, nil)
+
+	// Act
+	id, err := s.sut.CreateContainerWithNetwork(ctx, serviceContainer)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(containerID, id)
+}
+
+func (s *actionsTestSuite) TestCreateContainerWithNetwork_WhenVolumesAreMounted_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+	serviceContainer := docker.Container{
+		Name:  "container",
+		Image: "image",
+		Mounts: []docker.Mount{
+			{Type: "volume", Source: "data", Target: "/data", NoCopy: true},
+			{Type: "bind", Source: "/config", Target: "/config", ReadOnly: true, Propagation: "rshared"},
+		},
+	}
+	containerID := "id"
+
+	containerConfig := &container.Config{Image: serviceContainer.Image, Labels: serviceContainer.ComposeLabels()}
+	hostConfig := &container.HostConfig{
+		Mounts: []mount.Mount{
+			{Type: mount.TypeVolume, Source: "data", Target: "/data", VolumeOptions: &mount.VolumeOptions{NoCopy: true}},
+			{Type: mount.TypeBind, Source: "/config", Target: "/config", ReadOnly: true, BindOptions: &mount.BindOptions{Propagation: mount.PropagationRShared}},
+		},
+	}
+	containerCreateCreatedBody := container.ContainerCreateCreatedBody{ID: containerID}
+	s.client.On("ContainerCreate", ctx, containerConfig, hostConfig, mock.Anything, mock.Anything, serviceContainer.Name).Return(containerCreateCreatedBody, nil)
+
+	// Act
+	id, err := s.sut.CreateContainerWithNetwork(ctx, serviceContainer)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(containerID, id)
+}
+
+func (s *actionsTestSuite) TestCreateContainerWithNetwork_WhenHealthcheckIsDefined_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+	serviceContainer := docker.Container{
+		Name:  "container",
+		Image: "image",
+		Healthcheck: &docker.Healthcheck{
+			Test:     []string{"CMD", "true"},
+			Interval: time.Second,
+			Retries:  3,
+		},
+	}
+	containerID := "id"
+
+	containerConfig := &container.Config{
+		Image:  serviceContainer.Image,
+		Labels: serviceContainer.ComposeLabels(),
+		Healthcheck: &container.HealthConfig{
+			Test:     []string{"CMD", "true"},
+			Interval: time.Second,
+			Retries:  3,
+		},
+	}
+	containerCreateCreatedBody := container.ContainerCreateCreatedBody{ID: containerID}
+	s.client.On("ContainerCreate", ctx, containerConfig, mock.Anything, mock.Anything, mock.Anything, serviceContainer.Name).Return(containerCreateCreatedBody, nil)
+
+	// Act
+	id, err := s.sut.CreateContainerWithNetwork(ctx, serviceContainer)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(containerID, id)
+}
+
+func (s *actionsTestSuite) TestCreateContainerWithNetwork_WhenCommandIsOverridden_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+	serviceContainer := docker.Container{
+		Name:       "container",
+		Image:      "image",
+		Command:    []string{"-m", "512"},
+		Entrypoint: []string{"memcached"},
+		WorkingDir: "/app",
+		User:       "1000:1000",
+		Hostname:   "cache",
+		Tty:        true,
+		StdinOpen:  true,
+	}
+	containerID := "id"
+
+	containerConfig := &container.Config{
+		Image:      serviceContainer.Image,
+		Labels:     serviceContainer.ComposeLabels(),
+		Cmd:        strslice.StrSlice{"-m", "512"},
+		Entrypoint: strslice.StrSlice{"memcached"},
+		WorkingDir: "/app",
+		User:       "1000:1000",
+		Hostname:   "cache",
+		Tty:        true,
+		OpenStdin:  true,
+	}
+	containerCreateCreatedBody := container.ContainerCreateCreatedBody{ID: containerID}
+	s.client.On("ContainerCreate", ctx, containerConfig, mock.Anything, mock.Anything, mock.Anything, serviceContainer.Name).Return(containerCreateCreatedBody, nil)
+
+	// Act
+	id, err := s.sut.CreateContainerWithNetwork(ctx, serviceContainer)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(containerID, id)
+}
+
+func (s *actionsTestSuite) TestCreateContainerWithNetwork_WhenResourcesAreLimited_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+	serviceContainer := docker.Container{
+		Name:          "container",
+		Image:         "image",
+		RestartPolicy: &docker.RestartPolicy{Name: "on-failure", MaximumRetryCount: 3},
+		Resources: &docker.Resources{
+			Memory:            512,
+			MemoryReservation: 256,
+			NanoCPUs:          1500000000,
+			CPUShares:         1024,
+			PidsLimit:         100,
+			ShmSize:           64,
+		},
+	}
 	containerID := "id"
 
-	containerConfig := &container.Config{Image: imageName}
+	pidsLimit := int64(100)
+	hostConfig := &container.HostConfig{
+		RestartPolicy: container.RestartPolicy{Name: "on-failure", MaximumRetryCount: 3},
+		ShmSize:       64,
+		Resources: container.Resources{
+			Memory:            512,
+			MemoryReservation: 256,
+			NanoCPUs:          1500000000,
+			CPUShares:         1024,
+			PidsLimit:         &pidsLimit,
+		},
+	}
 	containerCreateCreatedBody := container.ContainerCreateCreatedBody{ID: containerID}
-	s.client.On("ContainerCreate", ctx, containerConfig, mock.Anything, mock.Anything, mock.Anything, containerName).Return(containerCreateCreatedBody, nil)
-	s.client.On("NetworkConnect", ctx, networkID, containerID, mock.Anything).Return(nil)
+	s.client.On("ContainerCreate", ctx, mock.Anything, hostConfig, mock.Anything, mock.Anything, serviceContainer.Name).Return(containerCreateCreatedBody, nil)
 
 	// Act
-	id, err := s.sut.CreateContainerWithNetwork(ctx, imageName, containerName, networkID, nil)
+	id, err := s.sut.CreateContainerWithNetwork(ctx, serviceContainer)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(containerID, id)
+}
+
+func (s *actionsTestSuite) TestCreateContainerWithNetwork_WhenSecurityOptionsAreSet_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+	serviceContainer := docker.Container{
+		Name:        "container",
+		Image:       "image",
+		Privileged:  true,
+		CapAdd:      []string{"NET_ADMIN"},
+		CapDrop:     []string{"ALL"},
+		ReadOnly:    true,
+		SecurityOpt: []string{"no-new-privileges:true"},
+		Tmpfs:       map[string]string{"/run": "size=64m"},
+		Sysctls:     map[string]string{"net.core.somaxconn": "1024"},
+		Ulimits:     []docker.Ulimit{{Name: "nofile", Soft: 20000, Hard: 40000}},
+	}
+	containerID := "id"
+
+	hostConfig := &container.HostConfig{
+		Privileged:     true,
+		CapAdd:         strslice.StrSlice{"NET_ADMIN"},
+		CapDrop:        strslice.StrSlice{"ALL"},
+		ReadonlyRootfs: true,
+		SecurityOpt:    []string{"no-new-privileges:true"},
+		Tmpfs:          map[string]string{"/run": "size=64m"},
+		Sysctls:        map[string]string{"net.core.somaxconn": "1024"},
+		Resources: container.Resources{
+			Ulimits: []*units.Ulimit{{Name: "nofile", Soft: 20000, Hard: 40000}},
+		},
+	}
+	containerCreateCreatedBody := container.ContainerCreateCreatedBody{ID: containerID}
+	s.client.On("ContainerCreate", ctx, mock.Anything, hostConfig, mock.Anything, mock.Anything, serviceContainer.Name).Return(containerCreateCreatedBody, nil)
+
+	// Act
+	id, err := s.sut.CreateContainerWithNetwork(ctx, serviceContainer)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(containerID, id)
+}
+
+func (s *actionsTestSuite) TestCreateContainerWithNetwork_WhenLabelsAndLoggingAreSet_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+	serviceContainer := docker.Container{
+		Name:    "container",
+		Project: "project",
+		Image:   "image",
+		Labels: map[string]string{
+			"traefik.enable":      "true",
+			docker.LabelProject:   "overridden",
+			"com.example.service": "web",
+		},
+		ExtraHosts:      []string{"db.local:10.0.0.2"},
+		DNS:             []string{"8.8.8.8"},
+		DNSSearch:       []string{"example.com"},
+		Domainname:      "example.com",
+		Logging:         &docker.Logging{Driver: "json-file", Options: map[string]string{"max-size": "10m"}},
+		StopSignal:      "SIGINT",
+		StopGracePeriod: 1500 * time.Millisecond,
+	}
+	containerID := "id"
+
+	labels := serviceContainer.ComposeLabels()
+	labels["traefik.enable"] = "true"
+	labels["com.example.service"] = "web"
+	stopTimeout := 2
+	containerConfig := &container.Config{
+		Image:       serviceContainer.Image,
+		Labels:      labels,
+		Domainname:  "example.com",
+		StopSignal:  "SIGINT",
+		StopTimeout: &stopTimeout,
+	}
+	hostConfig := &container.HostConfig{
+		ExtraHosts: []string{"db.local:10.0.0.2"},
+		DNS:        []string{"8.8.8.8"},
+		DNSSearch:  []string{"example.com"},
+		LogConfig:  container.LogConfig{Type: "json-file", Config: map[string]string{"max-size": "10m"}},
+	}
+	containerCreateCreatedBody := container.ContainerCreateCreatedBody{ID: containerID}
+	s.client.On("ContainerCreate", ctx, containerConfig, hostConfig, mock.Anything, mock.Anything, serviceContainer.Name).Return(containerCreateCreatedBody, nil)
+
+	// Act
+	id, err := s.sut.CreateContainerWithNetwork(ctx, serviceContainer)
 
 	// Assert
 	s.NoError(err)
@@ -180,16 +825,14 @@ func (s *actionsTestSuite) TestCreateContainerWithNetwork_ThenSuccess() {
 func (s *actionsTestSuite) TestCreateContainerWithNetwork_WhenErrorOccursOnContainerCreation_ThenFailure() {
 	// Arrange
 	ctx := context.Background()
-	imageName := "image"
-	containerName := "container"
-	networkID := "id"
+	serviceContainer := docker.Container{Name: "container", Image: "image"}
 
-	containerConfig := &container.Config{Image: imageName}
+	containerConfig := &container.Config{Image: serviceContainer.Image, Labels: serviceContainer.ComposeLabels()}
 	containerCreateCreatedBody := container.ContainerCreateCreatedBody{}
-	s.client.On("ContainerCreate", ctx, containerConfig, mock.Anything, mock.Anything, mock.Anything, containerName).Return(containerCreateCreatedBody, errors.New("error"))
+	s.client.On("ContainerCreate", ctx, containerConfig, mock.Anything, mock.Anything, mock.Anything, serviceContainer.Name).Return(containerCreateCreatedBody, errors.New("error"))
 
 	// Act
-	id, err := s.sut.CreateContainerWithNetwork(ctx, imageName, containerName, networkID, nil)
+	id, err := s.sut.CreateContainerWithNetwork(ctx, serviceContainer)
 
 	// Assert
 	s.Error(err)
@@ -199,18 +842,20 @@ func (s *actionsTestSuite) TestCreateContainerWithNetwork_WhenErrorOccursOnConta
 func (s *actionsTestSuite) TestCreateContainerWithNetwork_WhenErrorOccursOnNetowrkConnection_ThenFailure() {
 	// Arrange
 	ctx := context.Background()
-	imageName := "image"
-	containerName := "container"
-	networkID := "id"
+	serviceContainer := docker.Container{
+		Name:     "container",
+		Image:    "image",
+		Networks: []docker.Network{{Name: "front"}, {Name: "back"}},
+	}
 	containerID := "id"
 
-	containerConfig := &container.Config{Image: imageName}
+	containerConfig := &container.Config{Image: serviceContainer.Image, Labels: serviceContainer.ComposeLabels()}
 	containerCreateCreatedBody := container.ContainerCreateCreatedBody{ID: containerID}
-	s.client.On("ContainerCreate", ctx, containerConfig, mock.Anything, mock.Anything, mock.Anything, containerName).Return(containerCreateCreatedBody, nil)
-	s.client.On("NetworkConnect", ctx, networkID, containerID, mock.Anything).Return(errors.New("error"))
+	s.client.On("ContainerCreate", ctx, containerConfig, mock.Anything, mock.Anything, mock.Anything, serviceContainer.Name).Return(containerCreateCreatedBody, nil)
+	s.client.On("NetworkConnect", ctx, "back", containerID, mock.Anything).Return(errors.New("error"))
 
 	// Act
-	id, err := s.sut.CreateContainerWithNetwork(ctx, imageName, containerName, networkID, nil)
+	id, err := s.sut.CreateContainerWithNetwork(ctx, serviceContainer)
 
 	// Assert
 	s.Error(err)
@@ -245,39 +890,301 @@ func (s *actionsTestSuite) TestStartContainer_ThenFailure() {
 	s.Error(err)
 }
 
-func (s *actionsTestSuite) TestStopContainer_ThenSuccess() {
+func (s *actionsTestSuite) TestInspectContainer_ThenSuccess() {
 	// Arrange
 	ctx := context.Background()
 	containerName := "container"
-	containerID := "id"
+
+	containerJSON := types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{ID: "id"}}
+	s.client.On("ContainerInspect", ctx, containerName).Return(containerJSON, nil)
+
+	// Act
+	result, err := s.sut.InspectContainer(ctx, containerName)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(containerJSON, result)
+}
+
+func (s *actionsTestSuite) TestInspectContainer_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	containerName := "container"
+
+	s.client.On("ContainerInspect", ctx, containerName).Return(types.ContainerJSON{}, errors.New("error"))
+
+	// Act
+	_, err := s.sut.InspectContainer(ctx, containerName)
+
+	// Assert
+	s.Error(err)
+}
+
+func (s *actionsTestSuite) TestListContainers_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
 
 	filter := filters.NewArgs()
-	filter.Add("name", containerName)
+	filter.Add("name", "^/db$")
+	filter.Add("name", "^/cache$")
+	containerListOptions := types.ContainerListOptions{All: true, Filters: filter}
+	containers := []types.Container{
+		{ID: "id1", Names: []string{"/db"}},
+		{ID: "id2", Names: []string{"/mydb-old"}},
+		{ID: "id3", Names: []string{"/cache"}},
+	}
+	s.client.On("ContainerList", ctx, containerListOptions).Return(containers, nil)
+
+	// Act
+	result, err := s.sut.ListContainers(ctx, []string{"db", "cache"}, true)
+
+	// Assert
+	s.NoError(err)
+	s.Equal([]types.Container{containers[0], containers[2]}, result)
+}
+
+func (s *actionsTestSuite) TestListContainers_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+
+	filter := filters.NewArgs()
+	filter.Add("name", "^/db$")
 	containerListOptions := types.ContainerListOptions{Filters: filter}
-	containers := []types.Container{{ID: containerID}}
+	s.client.On("ContainerList", ctx, containerListOptions).Return(nil, errors.New("error"))
+
+	// Act
+	result, err := s.sut.ListContainers(ctx, []string{"db"}, false)
+
+	// Assert
+	s.Error(err)
+	s.Empty(result)
+}
+
+func (s *actionsTestSuite) TestContainerLogs_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+	containerName := "containerName"
+	options := docker.LogOptions{Follow: true, Tail: "10", Since: "30m", Timestamps: true}
+	containerLogsOptions := types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+		Tail:       "10",
+		Since:      "30m",
+		Timestamps: true,
+	}
+	reader := io.NopCloser(strings.NewReader("logs"))
+
+	s.client.On("ContainerLogs", ctx, containerName, containerLogsOptions).Return(reader, nil)
+
+	// Act
+	result, err := s.sut.ContainerLogs(ctx, containerName, options)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(reader, result)
+}
+
+func (s *actionsTestSuite) TestContainerLogs_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	containerName := "containerName"
+	containerLogsOptions := types.ContainerLogsOptions{ShowStdout: true, ShowStderr: true}
+
+	s.client.On("ContainerLogs", ctx, containerName, containerLogsOptions).Return(nil, errors.New("error"))
+
+	// Act
+	result, err := s.sut.ContainerLogs(ctx, containerName, docker.LogOptions{})
+
+	// Assert
+	s.Error(err)
+	s.Nil(result)
+}
+
+func (s *actionsTestSuite) TestCreateExec_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+	containerName := "containerName"
+	options := docker.ExecOptions{
+		Command:     []string{"mysql", "-uroot"},
+		User:        "mysql",
+		WorkingDir:  "/var/lib/mysql",
+		Env:         []string{"KEY=value"},
+		Tty:         true,
+		Interactive: true,
+	}
+	execConfig := types.ExecConfig{
+		User:         "mysql",
+		Tty:          true,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		Env:          []string{"KEY=value"},
+		WorkingDir:   "/var/lib/mysql",
+		Cmd:          []string{"mysql", "-uroot"},
+	}
+
+	s.client.On("ContainerExecCreate", ctx, containerName, execConfig).Return(types.IDResponse{ID: "execID"}, nil)
+
+	// Act
+	result, err := s.sut.CreateExec(ctx, containerName, options)
+
+	// Assert
+	s.NoError(err)
+	s.Equal("execID", result)
+}
+
+func (s *actionsTestSuite) TestCreateExec_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	containerName := "containerName"
+	execConfig := types.ExecConfig{AttachStdout: true, AttachStderr: true, Cmd: []string{"ls"}}
+
+	s.client.On("ContainerExecCreate", ctx, containerName, execConfig).Return(types.IDResponse{}, errors.New("error"))
+
+	// Act
+	result, err := s.sut.CreateExec(ctx, containerName, docker.ExecOptions{Command: []string{"ls"}})
+
+	// Assert
+	s.Error(err)
+	s.Empty(result)
+}
+
+func (s *actionsTestSuite) TestAttachExec_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+	response := types.HijackedResponse{}
+
+	s.client.On("ContainerExecAttach", ctx, "execID", types.ExecStartCheck{Tty: true}).Return(response, nil)
+
+	// Act
+	result, err := s.sut.AttachExec(ctx, "execID", true)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(response, result)
+}
+
+func (s *actionsTestSuite) TestResizeExec_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+
+	s.client.On("ContainerExecResize", ctx, "execID", types.ResizeOptions{Height: 40, Width: 120}).Return(nil)
+
+	// Act
+	err := s.sut.ResizeExec(ctx, "execID", docker.TerminalSize{Height: 40, Width: 120})
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *actionsTestSuite) TestInspectExec_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+
+	s.client.On("ContainerExecInspect", ctx, "execID").Return(types.ContainerExecInspect{ExitCode: 3}, nil)
+
+	// Act
+	result, err := s.sut.InspectExec(ctx, "execID")
+
+	// Assert
+	s.NoError(err)
+	s.Equal(3, result)
+}
+
+func (s *actionsTestSuite) TestInspectExec_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+
+	s.client.On("ContainerExecInspect", ctx, "execID").Return(types.ContainerExecInspect{}, errors.New("error"))
+
+	// Act
+	result, err := s.sut.InspectExec(ctx, "execID")
+
+	// Assert
+	s.Error(err)
+	s.Zero(result)
+}
+
+func (s *actionsTestSuite) TestStopContainer_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+	serviceContainer := docker.Container{Name: "project-db", Project: "project", Service: "db"}
+	containerID := "id"
+
+	filter := filters.NewArgs()
+	filter.Add("label", docker.LabelProject+"=project")
+	filter.Add("label", docker.LabelService+"=db")
+	filter.Add("name", "^/project-db$")
+	containerListOptions := types.ContainerListOptions{All: true, Filters: filter}
+	containers := []types.Container{{ID: containerID, Names: []string{"/project-db"}, State: "running"}}
 	s.client.On("ContainerList", ctx, containerListOptions).Return(containers, nil)
-	s.client.On("ContainerStop", ctx, containerID, mock.Anything).Return(nil)
+	s.client.On("ContainerStop", ctx, containerID, (*time.Duration)(nil)).Return(nil)
+
+	// Act
+	id, err := s.sut.StopContainer(ctx, serviceContainer)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(containerID, id)
+}
+
+func (s *actionsTestSuite) TestStopContainer_WhenStopGracePeriodIsSet_ThenItIsUsedAsTimeout() {
+	// Arrange
+	ctx := context.Background()
+	serviceContainer := docker.Container{Name: "project-db", Project: "project", Service: "db", StopGracePeriod: 90 * time.Second}
+	containerID := "id"
+	timeout := 90 * time.Second
+
+	containers := []types.Container{{ID: containerID, Names: []string{"/project-db"}, State: "running"}}
+	s.client.On("ContainerList", ctx, mock.Anything).Return(containers, nil)
+	s.client.On("ContainerStop", ctx, containerID, &timeout).Return(nil)
 
 	// Act
-	id, err := s.sut.StopContainer(ctx, containerName)
+	id, err := s.sut.StopContainer(ctx, serviceContainer)
 
 	// Assert
 	s.NoError(err)
 	s.Equal(containerID, id)
 }
 
+func (s *actionsTestSuite) TestStopContainer_WhenContainerHasExited_ThenItIsOnlyFound() {
+	// Arrange
+	ctx := context.Background()
+	serviceContainer := docker.Container{Name: "project-db", Project: "project", Service: "db"}
+	containerID := "id"
+
+	filter := filters.NewArgs()
+	filter.Add("label", docker.LabelProject+"=project")
+	filter.Add("label", docker.LabelService+"=db")
+	filter.Add("name", "^/project-db$")
+	containerListOptions := types.ContainerListOptions{All: true, Filters: filter}
+	containers := []types.Container{{ID: containerID, Names: []string{"/project-db"}, State: "exited"}}
+	s.client.On("ContainerList", ctx, containerListOptions).Return(containers, nil)
+
+	// Act
+	id, err := s.sut.StopContainer(ctx, serviceContainer)
+
+	// Assert
+	s.NoError(err)
+	s.Equal(containerID, id)
+	s.client.AssertNotCalled(s.T(), "ContainerStop", ctx, containerID, mock.Anything)
+}
+
 func (s *actionsTestSuite) TestStopContainer_WhenErrorOccursOnContainerListing_ThenFailure() {
 	// Arrange
 	ctx := context.Background()
-	containerName := "container"
+	serviceContainer := docker.Container{Name: "project-db", Project: "project", Service: "db"}
 
 	filter := filters.NewArgs()
-	filter.Add("name", containerName)
-	containerListOptions := types.ContainerListOptions{Filters: filter}
+	filter.Add("label", docker.LabelProject+"=project")
+	filter.Add("label", docker.LabelService+"=db")
+	filter.Add("name", "^/project-db$")
+	containerListOptions := types.ContainerListOptions{All: true, Filters: filter}
 	s.client.On("ContainerList", ctx, containerListOptions).Return(nil, errors.New("error"))
 
 	// Act
-	id, err := s.sut.StopContainer(ctx, containerName)
+	id, err := s.sut.StopContainer(ctx, serviceContainer)
 
 	// Assert
 	s.Error(err)
@@ -287,37 +1194,116 @@ func (s *actionsTestSuite) TestStopContainer_WhenErrorOccursOnContainerListing_T
 func (s *actionsTestSuite) TestStopContainer_WhenNoContainersFound_ThenSuccess() {
 	// Arrange
 	ctx := context.Background()
-	containerName := "container"
+	serviceContainer := docker.Container{Name: "project-db", Project: "project", Service: "db"}
 
 	filter := filters.NewArgs()
-	filter.Add("name", containerName)
-	containerListOptions := types.ContainerListOptions{Filters: filter}
+	filter.Add("label", docker.LabelProject+"=project")
+	filter.Add("label", docker.LabelService+"=db")
+	filter.Add("name", "^/project-db$")
+	containerListOptions := types.ContainerListOptions{All: true, Filters: filter}
 	containers := []types.Container{}
 	s.client.On("ContainerList", ctx, containerListOptions).Return(containers, nil)
 
 	// Act
-	id, err := s.sut.StopContainer(ctx, containerName)
+	id, err := s.sut.StopContainer(ctx, serviceContainer)
 
 	// Assert
 	s.NoError(err)
 	s.Equal("", id)
 }
 
+func (s *actionsTestSuite) TestStopContainer_WhenOnlySimilarNamesMatch_ThenNothingIsStopped() {
+	// Arrange
+	ctx := context.Background()
+	serviceContainer := docker.Container{Name: "project-db", Project: "project", Service: "db"}
+
+	filter := filters.NewArgs()
+	filter.Add("label", docker.LabelProject+"=project")
+	filter.Add("label", docker.LabelService+"=db")
+	filter.Add("name", "^/project-db$")
+	containerListOptions := types.ContainerListOptions{All: true, Filters: filter}
+	containers := []types.Container{{ID: "id", Names: []string{"/project-db-legacy"}}}
+	s.client.On("ContainerList", ctx, containerListOptions).Return(containers, nil)
+
+	// Act
+	id, err := s.sut.StopContainer(ctx, serviceContainer)
+
+	// Assert
+	s.NoError(err)
+	s.Equal("", id)
+}
+
+func (s *actionsTestSuite) TestStopContainer_WhenMultipleContainersMatch_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	serviceContainer := docker.Container{Name: "project-db", Project: "project", Service: "db"}
+
+	filter := filters.NewArgs()
+	filter.Add("label", docker.LabelProject+"=project")
+	filter.Add("label", docker.LabelService+"=db")
+	filter.Add("name", "^/project-db$")
+	containerListOptions := types.ContainerListOptions{All: true, Filters: filter}
+	containers := []types.Container{
+		{ID: "id1", Names: []string{"/project-db"}},
+		{ID: "id2", Names: []string{"/project-db"}},
+	}
+	s.client.On("ContainerList", ctx, containerListOptions).Return(containers, nil)
+
+	// Act
+	id, err := s.sut.StopContainer(ctx, serviceContainer)
+
+	// Assert
+	s.EqualError(err, "multiple containers match project-db: project-db (id1), project-db (id2)")
+	s.Equal("", id)
+}
+
 func (s *actionsTestSuite) TestStopContainer_WhenErrorOccursOnContainerStopping_ThenFailure() {
 	// Arrange
 	ctx := context.Background()
-	containerName := "container"
+	serviceContainer := docker.Container{Name: "project-db", Project: "project", Service: "db"}
 	containerID := "id"
 
 	filter := filters.NewArgs()
-	filter.Add("name", containerName)
-	containerListOptions := types.ContainerListOptions{Filters: filter}
-	containers := []types.Container{{ID: containerID}}
+	filter.Add("label", docker.LabelProject+"=project")
+	filter.Add("label", docker.LabelService+"=db")
+	filter.Add("name", "^/project-db$")
+	containerListOptions := types.ContainerListOptions{All: true, Filters: filter}
+	containers := []types.Container{{ID: containerID, Names: []string{"/project-db"}, State: "running"}}
 	s.client.On("ContainerList", ctx, containerListOptions).Return(containers, nil)
 	s.client.On("ContainerStop", ctx, containerID, mock.Anything).Return(errors.New("error"))
 
 	// Act
-	_, err := s.sut.StopContainer(ctx, containerName)
+	_, err := s.sut.StopContainer(ctx, serviceContainer)
+
+	// Assert
+	s.Error(err)
+}
+
+func (s *actionsTestSuite) TestRestartContainer_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+	containerID := "id"
+	timeout := 5 * time.Second
+
+	s.client.On("ContainerRestart", ctx, containerID, &timeout).Return(nil)
+
+	// Act
+	err := s.sut.RestartContainer(ctx, containerID, timeout)
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *actionsTestSuite) TestRestartContainer_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	containerID := "id"
+	timeout := 5 * time.Second
+
+	s.client.On("ContainerRestart", ctx, containerID, &timeout).Return(errors.New("error"))
+
+	// Act
+	err := s.sut.RestartContainer(ctx, containerID, timeout)
 
 	// Assert
 	s.Error(err)
@@ -354,37 +1340,79 @@ func (s *actionsTestSuite) TestRemoveContainer_ThenFailure() {
 func (s *actionsTestSuite) TestRemoveNetwork_ThenSuccess() {
 	// Arrange
 	ctx := context.Background()
-	containerName := "container"
+	network := docker.Network{Name: "network", Project: "project"}
 	networkID := "id"
 
 	filter := filters.NewArgs()
-	filter.Add("name", fmt.Sprintf("%s-network", containerName))
+	filter.Add("name", "^network$")
+	filter.Add("label", docker.LabelProject+"=project")
 
 	networkListOptions := types.NetworkListOptions{Filters: filter}
-	networks := []types.NetworkResource{{ID: networkID}}
+	networks := []types.NetworkResource{{ID: networkID, Name: "network"}}
 	s.client.On("NetworkList", ctx, networkListOptions).Return(networks, nil)
 	s.client.On("NetworkRemove", ctx, networkID).Return(nil)
 
 	// Act
-	err := s.sut.RemoveNetwork(ctx, containerName)
+	err := s.sut.RemoveNetwork(ctx, network)
 
 	// Assert
 	s.NoError(err)
 }
 
+func (s *actionsTestSuite) TestRemoveNetwork_WhenNoNetworkMatches_ThenNotFound() {
+	// Arrange
+	ctx := context.Background()
+	network := docker.Network{Name: "network", Project: "project"}
+
+	filter := filters.NewArgs()
+	filter.Add("name", "^network$")
+	filter.Add("label", docker.LabelProject+"=project")
+
+	networkListOptions := types.NetworkListOptions{Filters: filter}
+	networks := []types.NetworkResource{{ID: "id", Name: "network-legacy"}}
+	s.client.On("NetworkList", ctx, networkListOptions).Return(networks, nil)
+
+	// Act
+	err := s.sut.RemoveNetwork(ctx, network)
+
+	// Assert
+	s.True(errdefs.IsNotFound(err))
+}
+
+func (s *actionsTestSuite) TestRemoveNetwork_WhenMultipleNetworksMatch_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	network := docker.Network{Name: "network", Project: "project"}
+
+	filter := filters.NewArgs()
+	filter.Add("name", "^network$")
+	filter.Add("label", docker.LabelProject+"=project")
+
+	networkListOptions := types.NetworkListOptions{Filters: filter}
+	networks := []types.NetworkResource{{ID: "id1", Name: "network"}, {ID: "id2", Name: "network"}}
+	s.client.On("NetworkList", ctx, networkListOptions).Return(networks, nil)
+
+	// Act
+	err := s.sut.RemoveNetwork(ctx, network)
+
+	// Assert
+	s.EqualError(err, "multiple networks match network: network (id1), network (id2)")
+}
+
 func (s *actionsTestSuite) TestRemoveNetwork_WhenErrorOccursOnNetworkList_ThenFailure() {
 	// Arrange
 	ctx := context.Background()
-	containerName := "container"
+	network := docker.Network{Name: "network", Project: "project"}
 
 	filter := filters.NewArgs()
-	filter.Add("name", fmt.Sprintf("%s-network", containerName))
+	filter.Add("name", "^network$")
+	filter.Add("label", docker.LabelProject+"=project")
 
 	networkListOptions := types.NetworkListOptions{Filters: filter}
 	s.client.On("NetworkList", ctx, networkListOptions).Return(nil, errors.New("error"))
 
 	// Act
-	err := s.sut.RemoveNetwork(ctx, containerName)
+	err := s.sut.RemoveNetwork(ctx, network)
 
 	// Assert
 	s.Error(err)
@@ -393,19 +1421,20 @@ func (s *actionsTestSuite) TestRemoveNetwork_WhenErrorOccursOnNetworkList_ThenFa
 func (s *actionsTestSuite) TestRemoveNetwork_WhenErrorOccursOnNetworkRemove_ThenFailure() {
 	// Arrange
 	ctx := context.Background()
-	containerName := "container"
+	network := docker.Network{Name: "network", Project: "project"}
 	networkID := "id"
 
 	filter := filters.NewArgs()
-	filter.Add("name", fmt.Sprintf("%s-network", containerName))
+	filter.Add("name", "^network$")
+	filter.Add("label", docker.LabelProject+"=project")
 
 	networkListOptions := types.NetworkListOptions{Filters: filter}
-	networks := []types.NetworkResource{{ID: networkID}}
+	networks := []types.NetworkResource{{ID: networkID, Name: "network"}}
 	s.client.On("NetworkList", ctx, networkListOptions).Return(networks, nil)
 	s.client.On("NetworkRemove", ctx, networkID).Return(errors.New("error"))
 
 	// Act
-	err := s.sut.RemoveNetwork(ctx, containerName)
+	err := s.sut.RemoveNetwork(ctx, network)
 
 	// Assert
 	s.Error(err)
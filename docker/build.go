@@ -0,0 +1,177 @@
+package docker
+
+import (
+	"archive/tar"
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/pkg/fileutils"
+)
+
+const (
+	defaultDockerfile    = "Dockerfile"
+	dockerignoreFileName = ".dockerignore"
+)
+
+// writeBuildContext writes the build context directory as a tar archive, leaving out the paths
+// excluded by its .dockerignore file. The Dockerfile and the .dockerignore file are always sent
+// so the daemon can read them.
+func writeBuildContext(out io.Writer, contextDir, dockerfile string) error {
+	patterns, err := readDockerignore(contextDir)
+	if err != nil {
+		return err
+	}
+
+	matcher, err := fileutils.NewPatternMatcher(patterns)
+	if err != nil {
+		return fmt.Errorf("invalid %s pattern: %s", dockerignoreFileName, err)
+	}
+	dockerfile = path.Clean(filepath.ToSlash(dockerfile))
+
+	archive := tar.NewWriter(out)
+	err = filepath.Walk(contextDir, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(contextDir, file)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+
+		name := filepath.ToSlash(rel)
+		if name == dockerfile || name == dockerignoreFileName {
+			return addToArchive(archive, file, name, info)
+		}
+
+		ignored, err := matcher.Matches(rel)
+		if err != nil {
+			return err
+		}
+		if ignored {
+			// Ignored directories are still walked when files inside them may be added back.
+			if info.IsDir() && !matcher.Exclusions() && !strings.HasPrefix(dockerfile, name+"/") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		return addToArchive(archive, file, name, info)
+	})
+	if err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+func addToArchive(archive *tar.Writer, file, name string, info os.FileInfo) error {
+	var link string
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		var err error
+		if link, err = os.Readlink(file); err != nil {
+			return err
+		}
+	case !info.Mode().IsRegular() && !info.IsDir():
+		// Sockets, pipes and devices cannot be part of a build context.
+		return nil
+	}
+
+	header, err := tar.FileInfoHeader(info, link)
+	if err != nil {
+		return err
+	}
+
+	header.Name = name
+	if info.IsDir() {
+		header.Name += "/"
+	}
+	// The owners of the files on the host mean nothing inside the image.
+	header.Uid, header.Gid, header.Uname, header.Gname = 0, 0, "", ""
+
+	if err = archive.WriteHeader(header); err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+
+	content, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	_, err = io.Copy(archive, content)
+	return err
+}
+
+// contextDockerfile returns the slash separated path of the Dockerfile relative to the build context.
+// The daemon reads the Dockerfile from the build context, so it cannot be outside of it.
+func contextDockerfile(contextDir, dockerfile string) (string, error) {
+	rel := dockerfile
+	if filepath.IsAbs(rel) {
+		var err error
+		if rel, err = filepath.Rel(contextDir, rel); err != nil {
+			return "", err
+		}
+	}
+
+	rel = path.Clean(filepath.ToSlash(rel))
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", fmt.Errorf("dockerfile %s is outside of the build context %s", dockerfile, contextDir)
+	}
+
+	return rel, nil
+}
+
+// readDockerignore reads the patterns of the .dockerignore file of the build context, if any.
+// The patterns are cleaned and made relative to the build context like the docker CLI does.
+func readDockerignore(contextDir string) ([]string, error) {
+	file, err := os.Open(filepath.Join(contextDir, dockerignoreFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		exclusion := strings.HasPrefix(line, "!")
+		pattern := strings.TrimSpace(strings.TrimPrefix(line, "!"))
+		if pattern != "" {
+			pattern = filepath.Clean(pattern)
+			if len(pattern) > 1 && pattern[0] == filepath.Separator {
+				pattern = pattern[1:]
+			}
+		}
+		if exclusion {
+			pattern = "!" + pattern
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return patterns, nil
+}
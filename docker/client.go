@@ -2,17 +2,44 @@ package docker
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	dockerClient "github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-units"
 
 	"github.com/petrovskiborislav/docker-cli/logger"
 )
 
 // Client provides interactions with the docker SDK.
 type Client interface {
-	ServiceProvisioning(ctx context.Context, container Container) error
+	ServiceProvisioning(ctx context.Context, container Container, options ProvisioningOptions) error
 	ServiceDecommissioning(ctx context.Context, container Container) error
+	RestartService(ctx context.Context, container Container, timeout time.Duration) error
+	WaitForServices(ctx context.Context, containerNames []string, condition string, timeout time.Duration) error
+	ListServices(ctx context.Context, containers []Container, all bool) ([]ServiceStatus, error)
+	StreamLogs(ctx context.Context, containers []Container, options LogOptions, out io.Writer) error
+	ExecService(ctx context.Context, container Container, options ExecOptions, streams ExecStreams) (int, error)
+	PullImages(ctx context.Context, containers []Container, parallelism int) []PullResult
 }
 
+const (
+	pollInterval           = time.Second
+	containerStatusCreated = "created"
+	containerStatusExited  = "exited"
+	containerStatusDead    = "dead"
+)
+
 type client struct {
 	logger  logger.Logger
 	actions Actions
@@ -23,25 +50,44 @@ func NewClient(logger logger.Logger, actions Actions) Client {
 	return &client{logger: logger, actions: actions}
 }
 
-// ServiceProvisioning creates and run a service within a container with isolated network.
-func (c client) ServiceProvisioning(ctx context.Context, container Container) error {
-	err := c.pullImageIfNotExists(ctx, container.Image)
+// ServiceProvisioning creates and run a service within a container connected to its networks.
+// An existing container is reused unless its configuration changed or recreation is forced.
+func (c client) ServiceProvisioning(ctx context.Context, container Container, options ProvisioningOptions) error {
+	err := c.provideImage(ctx, container, options.PullPolicy)
+	if err != nil {
+		return err
+	}
+
+	err = c.createVolumes(ctx, container)
 	if err != nil {
 		return err
 	}
 
-	networkName := fmt.Sprintf("%s-network", container.Name)
-	newNetworkID, err := c.actions.CreateNetwork(ctx, networkName)
+	err = c.createNetworks(ctx, container)
 	if err != nil {
 		return err
 	}
-	c.logger.Info("Successfully created network %s \n", networkName)
 
-	containerID, err := c.actions.CreateContainerWithNetwork(ctx, container.Image, container.Name, newNetworkID, container.EnvironmentVars)
+	existing, err := c.existingContainer(ctx, container, options)
 	if err != nil {
 		return err
 	}
-	c.logger.Info("Successfully created container %s\n", container.Name)
+
+	if existing != nil && existing.State != nil && existing.State.Running {
+		c.logger.Warn("Container %s is already running skipping\n", container.Name)
+		return nil
+	}
+
+	var containerID string
+	if existing != nil {
+		containerID = existing.ID
+	} else {
+		containerID, err = c.actions.CreateContainerWithNetwork(ctx, container)
+		if err != nil {
+			return err
+		}
+		c.logger.Info("Successfully created container %s\n", container.Name)
+	}
 
 	err = c.actions.StartContainer(ctx, containerID)
 	if err != nil {
@@ -53,9 +99,83 @@ func (c client) ServiceProvisioning(ctx context.Context, container Container) er
 	return nil
 }
 
-// ServiceDecommissioning stops and removes a service container and its isolated network.
+// existingContainer returns the container previously created for the service or nil when there
+// is none. A container which has to be recreated, because its configuration or its image changed,
+// is removed.
+func (c client) existingContainer(ctx context.Context, container Container, options ProvisioningOptions) (*types.ContainerJSON, error) {
+	containerJSON, err := c.actions.InspectContainer(ctx, container.Name)
+	if dockerClient.IsErrNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = checkOwnership(containerJSON, container); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case options.NoRecreate:
+		return &containerJSON, nil
+	case options.ForceRecreate:
+		c.logger.Warn("Recreating container %s\n", container.Name)
+	case configurationChanged(containerJSON, container):
+		c.logger.Warn("Configuration of container %s changed recreating\n", container.Name)
+	default:
+		// An image pulled or built again under the same name has a new ID.
+		imageID, err := c.actions.InspectImage(ctx, container.Image)
+		if err != nil {
+			return nil, err
+		}
+		if containerJSON.ContainerJSONBase != nil && containerJSON.Image == imageID {
+			return &containerJSON, nil
+		}
+		c.logger.Warn("Image of container %s changed recreating\n", container.Name)
+	}
+
+	if containerJSON.State != nil && containerJSON.State.Running {
+		if _, err := c.actions.StopContainer(ctx, container); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.actions.RemoveContainer(ctx, containerJSON.ID); err != nil {
+		return nil, err
+	}
+	c.logger.Info("Successfully removed container %s\n", container.Name)
+
+	return nil, nil
+}
+
+// checkOwnership fails when the container holding the name of the service was not created for it by
+// the project, so containers which are not ours are neither reused nor removed.
+func checkOwnership(containerJSON types.ContainerJSON, container Container) error {
+	var labels map[string]string
+	if containerJSON.Config != nil {
+		labels = containerJSON.Config.Labels
+	}
+
+	if labels[LabelProject] != container.Project || labels[LabelService] != container.Service {
+		return fmt.Errorf("container name %s is in use by a container not owned by project %s", container.Name, container.Project)
+	}
+
+	return nil
+}
+
+// configurationChanged reports whether the existing container was created from a different service
+// definition. Containers without a config hash label predate it and are considered changed.
+func configurationChanged(containerJSON types.ContainerJSON, container Container) bool {
+	if containerJSON.Config == nil {
+		return true
+	}
+
+	return containerJSON.Config.Labels[LabelConfigHash] != container.ConfigHash()
+}
+
+// ServiceDecommissioning stops and removes a service container and
+// the networks no other container is connected to anymore.
 func (c client) ServiceDecommissioning(ctx context.Context, container Container) error {
-	containerID, err := c.actions.StopContainer(ctx, container.Name)
+	containerID, err := c.actions.StopContainer(ctx, container)
 	if err != nil {
 		return err
 	}
@@ -73,31 +193,589 @@ func (c client) ServiceDecommissioning(ctx context.Context, container Container)
 	}
 	c.logger.Info("Successfully removed container %s\n", container.Name)
 
-	err = c.actions.RemoveNetwork(ctx, container.Name)
+	return c.removeUnusedNetworks(ctx, container)
+}
+
+// RestartService restarts the container of a service. The container is killed
+// when it does not stop within the timeout and a service without a container fails.
+func (c client) RestartService(ctx context.Context, container Container, timeout time.Duration) error {
+	containerJSON, err := c.actions.InspectContainer(ctx, container.Name)
+	if dockerClient.IsErrNotFound(err) {
+		return fmt.Errorf("container %s of service %s does not exist, run start to create it", container.Name, container.Service)
+	}
+	if err != nil {
+		return err
+	}
+	if err = checkOwnership(containerJSON, container); err != nil {
+		return err
+	}
+
+	err = c.actions.RestartContainer(ctx, containerJSON.ID, timeout)
 	if err != nil {
 		return err
 	}
-	c.logger.Info("Successfully removed network %s\n", container.Name)
+	c.logger.Info("Successfully restarted container %s\n", container.Name)
 
 	return nil
 }
 
-func (c client) pullImageIfNotExists(ctx context.Context, image string) error {
-	exists, err := c.actions.CheckIfImageExists(ctx, image)
+// WaitForServices polls the containers until all of them satisfy the condition. It fails
+// when the timeout expires or a container can no longer satisfy it, reporting every
+// container which did not become ready.
+func (c client) WaitForServices(ctx context.Context, containerNames []string, condition string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	pending := containerNames
+	var failures []string
+	for len(pending) > 0 {
+		var notReady []string
+		for _, containerName := range pending {
+			ready, err := c.checkCondition(ctx, containerName, condition)
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				notReady = append(notReady, containerName)
+				continue
+			}
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s (%s)", containerName, err))
+				continue
+			}
+
+			if ready {
+				c.logger.Info("Container %s is ready\n", containerName)
+				continue
+			}
+			notReady = append(notReady, containerName)
+		}
+
+		pending = notReady
+		if len(pending) == 0 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			for _, containerName := range pending {
+				failures = append(failures, fmt.Sprintf("%s (timed out)", containerName))
+			}
+			pending = nil
+		case <-time.After(pollInterval):
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("containers did not satisfy condition %s: %s", condition, strings.Join(failures, ", "))
+	}
+
+	return nil
+}
+
+// checkCondition reports whether the container satisfies the condition and
+// returns an error when the container can no longer satisfy it.
+func (c client) checkCondition(ctx context.Context, containerName string, condition string) (bool, error) {
+	containerJSON, err := c.actions.InspectContainer(ctx, containerName)
+	if err != nil {
+		return false, err
+	}
+
+	if containerJSON.ContainerJSONBase == nil || containerJSON.State == nil {
+		return false, nil
+	}
+
+	state := containerJSON.State
+	switch condition {
+	case ConditionCompleted:
+		if state.Running || state.Status == containerStatusCreated {
+			return false, nil
+		}
+		if state.ExitCode != 0 {
+			return false, fmt.Errorf("exited with code %d", state.ExitCode)
+		}
+		return true, nil
+	case ConditionHealthy:
+		if !state.Running {
+			return false, fmt.Errorf("container is %s", state.Status)
+		}
+		// Containers without a healthcheck are considered healthy once running.
+		if state.Health == nil {
+			return true, nil
+		}
+		switch state.Health.Status {
+		case types.Healthy:
+			return true, nil
+		case types.Unhealthy:
+			return false, errors.New("container is unhealthy")
+		}
+		return false, nil
+	default:
+		return state.Running || state.Status == containerStatusExited, nil
+	}
+}
+
+// ListServices returns the status of the containers running the services. Services without a
+// container are left out, as are stopped containers unless all is set.
+func (c client) ListServices(ctx context.Context, containers []Container, all bool) ([]ServiceStatus, error) {
+	var containerNames []string
+	for _, container := range containers {
+		containerNames = append(containerNames, container.Name)
+	}
+
+	dockerContainers, err := c.actions.ListContainers(ctx, containerNames, all)
+	if err != nil {
+		return nil, err
+	}
+
+	containersByName := make(map[string]types.Container, len(dockerContainers))
+	for _, dockerContainer := range dockerContainers {
+		for _, name := range dockerContainer.Names {
+			containersByName[strings.TrimPrefix(name, "/")] = dockerContainer
+		}
+	}
+
+	var statuses []ServiceStatus
+	for _, container := range containers {
+		dockerContainer, ok := containersByName[container.Name]
+		if !ok {
+			continue
+		}
+
+		status := ServiceStatus{
+			Service:     container.Service,
+			ContainerID: dockerContainer.ID,
+			Image:       dockerContainer.Image,
+			State:       dockerContainer.State,
+			Ports:       formatPorts(dockerContainer.Ports),
+		}
+
+		if dockerContainer.NetworkSettings != nil {
+			for name := range dockerContainer.NetworkSettings.Networks {
+				status.Networks = append(status.Networks, name)
+			}
+			sort.Strings(status.Networks)
+		}
+
+		containerJSON, err := c.actions.InspectContainer(ctx, dockerContainer.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if containerJSON.ContainerJSONBase != nil && containerJSON.State != nil {
+			if containerJSON.State.Health != nil {
+				status.Health = containerJSON.State.Health.Status
+			}
+
+			startedAt, err := time.Parse(time.RFC3339Nano, containerJSON.State.StartedAt)
+			if containerJSON.State.Running && err == nil {
+				status.Uptime = units.HumanDuration(time.Since(startedAt))
+			}
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// StreamLogs writes the logs of the containers to out concurrently, prefixing every line with
+// the name of its service. It returns once all logs are read or the context is cancelled.
+func (c client) StreamLogs(ctx context.Context, containers []Container, options LogOptions, out io.Writer) error {
+	width := 0
+	for _, container := range containers {
+		if len(container.Service) > width {
+			width = len(container.Service)
+		}
+	}
+
+	var mu sync.Mutex
+	var failures []string
+	var wg sync.WaitGroup
+	for i, container := range containers {
+		wg.Add(1)
+		go func(index int, container Container) {
+			defer wg.Done()
+
+			writer := c.logger.PrefixWriter(out, fmt.Sprintf("%-*s", width, container.Service), index)
+			err := c.streamContainerLogs(ctx, container.Name, options, writer)
+			if err == nil || errors.Is(err, context.Canceled) {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			failures = append(failures, fmt.Sprintf("%s (%s)", container.Service, err))
+		}(i, container)
+	}
+	wg.Wait()
+
+	if len(failures) > 0 {
+		sort.Strings(failures)
+		return fmt.Errorf("reading logs failed: %s", strings.Join(failures, ", "))
+	}
+
+	return nil
+}
+
+func (c client) streamContainerLogs(ctx context.Context, containerName string, options LogOptions, writer *logger.PrefixWriter) error {
+	containerJSON, err := c.actions.InspectContainer(ctx, containerName)
+	if dockerClient.IsErrNotFound(err) {
+		c.logger.Warn("Container %s not found skipping\n", containerName)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	reader, err := c.actions.ContainerLogs(ctx, containerName, options)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	// Logs of containers with a TTY are a raw stream, the others multiplex stdout and stderr.
+	if containerJSON.Config != nil && containerJSON.Config.Tty {
+		_, err = io.Copy(writer, reader)
+	} else {
+		_, err = stdcopy.StdCopy(writer, writer, reader)
+	}
+	if err != nil {
+		return err
+	}
+
+	return writer.Flush()
+}
+
+// ExecService runs a command inside the running container of a service, connecting it to the
+// streams until the command exits. It returns the exit code of the command.
+func (c client) ExecService(ctx context.Context, container Container, options ExecOptions, streams ExecStreams) (int, error) {
+	execID, err := c.actions.CreateExec(ctx, container.Name, options)
+	if err != nil {
+		return 0, err
+	}
+
+	response, err := c.actions.AttachExec(ctx, execID, options.Tty)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Close()
+
+	if options.Tty && streams.Resize != nil {
+		go c.resizeExec(ctx, execID, streams.Resize)
+	}
+
+	outputDone := make(chan error, 1)
+	go func() {
+		var err error
+		// Output of a TTY session is a raw stream, otherwise stdout and stderr are multiplexed.
+		if options.Tty {
+			_, err = io.Copy(streams.Out, response.Reader)
+		} else {
+			_, err = stdcopy.StdCopy(streams.Out, streams.Err, response.Reader)
+		}
+		outputDone <- err
+	}()
+
+	if options.Interactive && streams.In != nil {
+		go func() {
+			_, _ = io.Copy(response.Conn, streams.In)
+			_ = response.CloseWrite()
+		}()
+	}
+
+	select {
+	case err := <-outputDone:
+		if err != nil {
+			return 0, err
+		}
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+
+	return c.actions.InspectExec(ctx, execID)
+}
+
+func (c client) resizeExec(ctx context.Context, execID string, resize <-chan TerminalSize) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case size, ok := <-resize:
+			if !ok {
+				return
+			}
+
+			if err := c.actions.ResizeExec(ctx, execID, size); err != nil {
+				c.logger.Warn("Error resizing terminal: %s\n", err)
+			}
+		}
+	}
+}
+
+// PullImages pulls the images of the containers, running at most parallelism pulls at a time. An image used
+// by several containers is pulled once. Images which are built or whose pull policy is never are skipped.
+// The results are ordered by image name.
+func (c client) PullImages(ctx context.Context, containers []Container, parallelism int) []PullResult {
+	var results []PullResult
+	indexes := make(map[string]int)
+	for _, container := range containers {
+		index, ok := indexes[container.Image]
+		if !ok {
+			index = len(results)
+			indexes[container.Image] = index
+			results = append(results, PullResult{Image: container.Image})
+		}
+
+		result := &results[index]
+		result.Services = append(result.Services, container.Service)
+		switch {
+		case container.Build != nil:
+			result.Skipped = "built from a Dockerfile"
+		case container.PullPolicy == PullPolicyNever:
+			result.Skipped = "pull policy is never"
+		}
+	}
+
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Every result is written by a single worker.
+			for index := range jobs {
+				results[index].Err = c.pullImage(ctx, results[index].Image)
+			}
+		}()
+	}
+
+	for index := range results {
+		if results[index].Skipped == "" {
+			jobs <- index
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Image < results[j].Image
+	})
+
+	return results
+}
+
+func formatPorts(ports []types.Port) []string {
+	var formatted []string
+	for _, port := range ports {
+		if port.PublicPort == 0 {
+			formatted = append(formatted, fmt.Sprintf("%d/%s", port.PrivatePort, port.Type))
+			continue
+		}
+
+		formatted = append(formatted, fmt.Sprintf("%s:%d->%d/%s", port.IP, port.PublicPort, port.PrivatePort, port.Type))
+	}
+	sort.Strings(formatted)
+
+	return formatted
+}
+
+// provideImage builds or pulls the image of the container following its pull policy. The pull policy
+// given when provisioning overrides the one of the container: the build policy when its image is
+// built and the other policies when it is not.
+func (c client) provideImage(ctx context.Context, container Container, pullPolicy string) error {
+	policy := container.PullPolicy
+	switch {
+	case pullPolicy == PullPolicyBuild && container.Build != nil:
+		policy = pullPolicy
+	case pullPolicy != "" && pullPolicy != PullPolicyBuild && container.Build == nil:
+		policy = pullPolicy
+	}
+
+	switch {
+	case policy == PullPolicyBuild && container.Build != nil:
+		return c.buildImage(ctx, container.Image, *container.Build)
+	case policy == PullPolicyAlways:
+		return c.pullImage(ctx, container.Image)
+	}
+
+	exists, err := c.actions.CheckIfImageExists(ctx, container.Image)
 	if err != nil {
 		return err
 	}
 
-	if exists {
+	switch {
+	case exists:
 		c.logger.Warn("Image already exists skipping\n")
 		return nil
+	case container.Build != nil:
+		return c.buildImage(ctx, container.Image, *container.Build)
+	case policy == PullPolicyNever:
+		return fmt.Errorf("image %s of service %s does not exist and its pull policy is %s", container.Image, container.Service, PullPolicyNever)
+	default:
+		return c.pullImage(ctx, container.Image)
 	}
+}
+
+// pullImage pulls the image and logs the progress messages of the pull as they arrive.
+func (c client) pullImage(ctx context.Context, image string) error {
+	c.logger.Info("Pulling image %s\n", image)
 
-	err = c.actions.PullImage(ctx, image)
+	reader, err := c.actions.PullImage(ctx, image)
 	if err != nil {
 		return err
 	}
+	defer reader.Close()
+
+	if err = c.logProgress(image, reader); err != nil {
+		return fmt.Errorf("error pulling image %s: %s", image, err)
+	}
 	c.logger.Info("Successfully pulled image %s\n", image)
 
 	return nil
 }
+
+// buildImage builds the image and logs the progress messages of the build as they arrive.
+func (c client) buildImage(ctx context.Context, image string, build Build) error {
+	c.logger.Info("Building image %s\n", image)
+
+	reader, err := c.actions.BuildImage(ctx, image, build)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	if err = c.logProgress(image, reader); err != nil {
+		return fmt.Errorf("error building image %s: %s", image, err)
+	}
+	c.logger.Info("Successfully built image %s\n", image)
+
+	return nil
+}
+
+// logProgress logs the JSON progress messages of a pull or a build of the image until the stream ends.
+// Lines are prefixed with the image as pulls run in parallel. Progress ticks are skipped and a layer
+// is only logged when its status changes. An error reported by one of the messages is returned.
+func (c client) logProgress(image string, reader io.Reader) error {
+	layerStatuses := make(map[string]string)
+	decoder := json.NewDecoder(reader)
+	for {
+		var message jsonmessage.JSONMessage
+		err := decoder.Decode(&message)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case message.Error != nil:
+			return errors.New(message.Error.Message)
+		case message.Stream != "":
+			c.logger.Info("%s: %s", image, message.Stream)
+		case message.ProgressMessage != "" || message.Progress != nil && (message.Progress.Current != 0 || message.Progress.Total != 0):
+			continue
+		case message.Status != "" && message.ID != "":
+			if layerStatuses[message.ID] == message.Status {
+				continue
+			}
+			layerStatuses[message.ID] = message.Status
+			c.logger.Info("%s: %s: %s\n", image, message.ID, message.Status)
+		case message.Status != "":
+			c.logger.Info("%s: %s\n", image, message.Status)
+		}
+	}
+}
+
+func (c client) createNetworks(ctx context.Context, container Container) error {
+	for _, network := range container.Networks {
+		_, err := c.actions.InspectNetwork(ctx, network.Name)
+		if err == nil {
+			continue
+		}
+
+		if !dockerClient.IsErrNotFound(err) {
+			return err
+		}
+
+		if network.External {
+			return fmt.Errorf("external network %s not found", network.Name)
+		}
+
+		_, err = c.actions.CreateNetwork(ctx, network)
+		if err != nil {
+			return err
+		}
+		c.logger.Info("Successfully created network %s\n", network.Name)
+	}
+
+	return nil
+}
+
+func (c client) removeUnusedNetworks(ctx context.Context, container Container) error {
+	for _, network := range container.Networks {
+		if network.External {
+			continue
+		}
+
+		networkResource, err := c.actions.InspectNetwork(ctx, network.Name)
+		if dockerClient.IsErrNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(networkResource.Containers) > 0 {
+			c.logger.Warn("Network %s is still in use skipping\n", network.Name)
+			continue
+		}
+
+		err = c.actions.RemoveNetwork(ctx, network)
+		if dockerClient.IsErrNotFound(err) {
+			c.logger.Warn("Network %s is not owned by the project skipping\n", network.Name)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		c.logger.Info("Successfully removed network %s\n", network.Name)
+	}
+
+	return nil
+}
+
+func (c client) createVolumes(ctx context.Context, container Container) error {
+	for _, volume := range container.Volumes {
+		if volume.External {
+			continue
+		}
+
+		err := c.actions.CreateVolume(ctx, volume)
+		if err != nil {
+			return err
+		}
+		c.logger.Info("Successfully created volume %s\n", volume.Name)
+	}
+
+	for _, mount := range container.Mounts {
+		if mount.Type != MountTypeBind {
+			continue
+		}
+
+		if _, err := os.Stat(mount.Source); !os.IsNotExist(err) {
+			continue
+		}
+
+		// Docker refuses to bind mount a missing host path so it is created the way the compose CLI does.
+		err := os.MkdirAll(mount.Source, 0755)
+		if err != nil {
+			return err
+		}
+		c.logger.Info("Successfully created bind mount source %s\n", mount.Source)
+	}
+
+	return nil
+}
@@ -1,11 +1,27 @@
 package docker_test
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/petrovskiborislav/docker-cli/docker"
@@ -34,18 +50,19 @@ func TestSuite_Client(t *testing.T) {
 func (s *clientTestSuite) TestServiceProvisioning_WhenImageExists_ThenSuccess() {
 	// Arrange
 	ctx := context.Background()
-	container := docker.Container{Name: "name", Image: "image"}
+	container := docker.Container{Name: "name", Image: "image", Networks: []docker.Network{{Name: "network"}}}
 	networkID := "networkID"
 	containerID := "containerID"
-	networkName := fmt.Sprintf("%s-network", container.Name)
 
 	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(true, nil)
-	s.actions.On("CreateNetwork", ctx, networkName).Return(networkID, nil)
-	s.actions.On("CreateContainerWithNetwork", ctx, container.Image, container.Name, networkID, container.EnvironmentVars).Return(containerID, nil)
+	s.actions.On("InspectNetwork", ctx, "network").Return(types.NetworkResource{}, errdefs.NotFound(errors.New("not found")))
+	s.actions.On("CreateNetwork", ctx, container.Networks[0]).Return(networkID, nil)
+	s.actions.On("InspectContainer", ctx, container.Name).Return(types.ContainerJSON{}, errdefs.NotFound(errors.New("not found")))
+	s.actions.On("CreateContainerWithNetwork", ctx, container).Return(containerID, nil)
 	s.actions.On("StartContainer", ctx, "containerID").Return(nil)
 
 	// Act
-	err := s.sut.ServiceProvisioning(ctx, container)
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{})
 
 	// Assert
 	s.NoError(err)
@@ -54,181 +71,1195 @@ func (s *clientTestSuite) TestServiceProvisioning_WhenImageExists_ThenSuccess()
 func (s *clientTestSuite) TestServiceProvisioning_WhenImageDoesNotExists_ThenSuccess() {
 	// Arrange
 	ctx := context.Background()
-	container := docker.Container{Name: "name", Image: "image"}
+	container := docker.Container{Name: "name", Image: "image", Networks: []docker.Network{{Name: "network"}}}
 	networkID := "networkID"
 	containerID := "containerID"
-	networkName := fmt.Sprintf("%s-network", container.Name)
 
 	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(false, nil)
-	s.actions.On("PullImage", ctx, container.Image).Return(nil)
-	s.actions.On("CreateNetwork", ctx, networkName).Return(networkID, nil)
-	s.actions.On("CreateContainerWithNetwork", ctx, container.Image, container.Name, networkID, container.EnvironmentVars).Return(containerID, nil)
+	s.actions.On("PullImage", ctx, container.Image).Return(pullProgress, nil)
+	s.actions.On("InspectNetwork", ctx, "network").Return(types.NetworkResource{}, errdefs.NotFound(errors.New("not found")))
+	s.actions.On("CreateNetwork", ctx, container.Networks[0]).Return(networkID, nil)
+	s.actions.On("InspectContainer", ctx, container.Name).Return(types.ContainerJSON{}, errdefs.NotFound(errors.New("not found")))
+	s.actions.On("CreateContainerWithNetwork", ctx, container).Return(containerID, nil)
 	s.actions.On("StartContainer", ctx, "containerID").Return(nil)
 
 	// Act
-	err := s.sut.ServiceProvisioning(ctx, container)
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{})
 
 	// Assert
 	s.NoError(err)
 }
 
-func (s *clientTestSuite) TestServiceProvisioning_WhenErrorOccursOnCheckingImageExists_ThenFailure() {
+func (s *clientTestSuite) TestServiceProvisioning_WhenBuildIsDefined_ThenImageIsBuilt() {
 	// Arrange
 	ctx := context.Background()
-	container := docker.Container{Name: "name", Image: "image"}
+	build := docker.Build{Context: "/project/web"}
+	container := docker.Container{Name: "name", Image: "project-web", Build: &build, Networks: []docker.Network{{Name: "network"}}}
+	progress := `{"stream":"Step 1/2 : FROM alpine\n"}` + "\n" + `{"status":"Pulling fs layer","id":"abc"}` + "\n"
 
-	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(false, errors.New("error"))
+	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(false, nil)
+	s.actions.On("BuildImage", ctx, container.Image, build).Return(io.NopCloser(strings.NewReader(progress)), nil)
+	s.actions.On("InspectNetwork", ctx, "network").Return(types.NetworkResource{}, nil)
+	s.actions.On("InspectContainer", ctx, container.Name).Return(types.ContainerJSON{}, errdefs.NotFound(errors.New("not found")))
+	s.actions.On("CreateContainerWithNetwork", ctx, container).Return("containerID", nil)
+	s.actions.On("StartContainer", ctx, "containerID").Return(nil)
 
 	// Act
-	err := s.sut.ServiceProvisioning(ctx, container)
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{})
 
 	// Assert
-	s.Error(err)
+	s.NoError(err)
+	s.actions.AssertNotCalled(s.T(), "PullImage", ctx, container.Image)
 }
 
-func (s *clientTestSuite) TestServiceProvisioning_WhenErrorOccursOnPullingImage_ThenFailure() {
+func (s *clientTestSuite) TestServiceProvisioning_WhenBuildReportsAnError_ThenFailure() {
 	// Arrange
 	ctx := context.Background()
-	container := docker.Container{Name: "name", Image: "image"}
+	build := docker.Build{Context: "/project/web"}
+	container := docker.Container{Name: "name", Image: "project-web", Build: &build}
+	progress := `{"stream":"Step 1/2 : FROM alpine\n"}` + "\n" + `{"errorDetail":{"message":"missing"},"error":"missing"}` + "\n"
+
+	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(false, nil)
+	s.actions.On("BuildImage", ctx, container.Image, build).Return(io.NopCloser(strings.NewReader(progress)), nil)
+
+	// Act
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{})
+
+	// Assert
+	s.Require().Error(err)
+	s.Equal("error building image project-web: missing", err.Error())
+}
+
+func (s *clientTestSuite) TestServiceProvisioning_WhenErrorOccursOnBuildingImage_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	build := docker.Build{Context: "/project/web"}
+	container := docker.Container{Name: "name", Image: "project-web", Build: &build}
 
 	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(false, nil)
-	s.actions.On("PullImage", ctx, container.Image).Return(errors.New("error"))
+	s.actions.On("BuildImage", ctx, container.Image, build).Return(nil, errors.New("error"))
 
 	// Act
-	err := s.sut.ServiceProvisioning(ctx, container)
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{})
 
 	// Assert
 	s.Error(err)
 }
 
-func (s *clientTestSuite) TestServiceProvisioning_WhenErrorOccursOnCreationOfNetwork_ThenFailure() {
+func (s *clientTestSuite) TestServiceProvisioning_WhenPullPolicyIsAlways_ThenExistingImageIsPulled() {
 	// Arrange
 	ctx := context.Background()
-	container := docker.Container{Name: "name", Image: "image"}
-	networkName := fmt.Sprintf("%s-network", container.Name)
+	container := docker.Container{Name: "name", Image: "image:latest", PullPolicy: docker.PullPolicyAlways}
+
+	s.actions.On("PullImage", ctx, container.Image).Return(pullProgress, nil)
+	s.actions.On("InspectContainer", ctx, container.Name).Return(types.ContainerJSON{}, errdefs.NotFound(errors.New("not found")))
+	s.actions.On("CreateContainerWithNetwork", ctx, container).Return("containerID", nil)
+	s.actions.On("StartContainer", ctx, "containerID").Return(nil)
+
+	// Act
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{})
+
+	// Assert
+	s.NoError(err)
+	s.actions.AssertNotCalled(s.T(), "CheckIfImageExists", ctx, container.Image)
+}
+
+func (s *clientTestSuite) TestServiceProvisioning_WhenPullPolicyIsNeverAndImageIsMissing_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "name", Service: "web", Image: "image", PullPolicy: docker.PullPolicyNever}
+
+	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(false, nil)
+
+	// Act
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{})
+
+	// Assert
+	s.Require().Error(err)
+	s.Equal("image image of service web does not exist and its pull policy is never", err.Error())
+	s.actions.AssertNotCalled(s.T(), "PullImage", ctx, container.Image)
+}
+
+func (s *clientTestSuite) TestServiceProvisioning_WhenPullOptionIsSet_ThenItOverridesPullPolicy() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "name", Image: "image", PullPolicy: docker.PullPolicyAlways}
 
 	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(true, nil)
-	s.actions.On("CreateNetwork", ctx, networkName).Return("", errors.New("error"))
+	s.actions.On("InspectContainer", ctx, container.Name).Return(types.ContainerJSON{}, errdefs.NotFound(errors.New("not found")))
+	s.actions.On("CreateContainerWithNetwork", ctx, container).Return("containerID", nil)
+	s.actions.On("StartContainer", ctx, "containerID").Return(nil)
 
 	// Act
-	err := s.sut.ServiceProvisioning(ctx, container)
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{PullPolicy: docker.PullPolicyMissing})
 
 	// Assert
-	s.Error(err)
+	s.NoError(err)
+	s.actions.AssertNotCalled(s.T(), "PullImage", ctx, container.Image)
 }
 
-func (s *clientTestSuite) TestServiceProvisioning_WhenErrorOccursOnCreatingContainerWithNetwork_ThenFailure() {
+func (s *clientTestSuite) TestServiceProvisioning_WhenBuildPullOptionIsSet_ThenOnlyBuiltImagesAreRebuilt() {
 	// Arrange
 	ctx := context.Background()
-	container := docker.Container{Name: "name", Image: "image"}
-	networkID := "networkID"
-	networkName := fmt.Sprintf("%s-network", container.Name)
+	build := docker.Build{Context: "/project/api"}
+	built := docker.Container{Name: "api", Image: "project-api", Build: &build}
+	pulled := docker.Container{Name: "cache", Image: "redis:7"}
+	options := docker.ProvisioningOptions{PullPolicy: docker.PullPolicyBuild}
+
+	s.actions.On("BuildImage", ctx, built.Image, build).Return(io.NopCloser(strings.NewReader(`{"stream":"Successfully built"}`)), nil)
+	s.actions.On("CheckIfImageExists", ctx, pulled.Image).Return(true, nil)
+	for _, container := range []docker.Container{built, pulled} {
+		s.actions.On("InspectContainer", ctx, container.Name).Return(types.ContainerJSON{}, errdefs.NotFound(errors.New("not found")))
+		s.actions.On("CreateContainerWithNetwork", ctx, container).Return(container.Name+"ID", nil)
+		s.actions.On("StartContainer", ctx, container.Name+"ID").Return(nil)
+	}
+
+	// Act
+	builtErr := s.sut.ServiceProvisioning(ctx, built, options)
+	pulledErr := s.sut.ServiceProvisioning(ctx, pulled, options)
+
+	// Assert
+	s.NoError(builtErr)
+	s.NoError(pulledErr)
+	s.actions.AssertNotCalled(s.T(), "CheckIfImageExists", ctx, built.Image)
+	s.actions.AssertNotCalled(s.T(), "PullImage", ctx, pulled.Image)
+}
+
+func (s *clientTestSuite) TestServiceProvisioning_WhenPullPolicyIsBuild_ThenExistingImageIsRebuilt() {
+	// Arrange
+	ctx := context.Background()
+	build := docker.Build{Context: "/project/web"}
+	container := docker.Container{Name: "name", Image: "project-web", Build: &build, PullPolicy: docker.PullPolicyBuild}
+
+	s.actions.On("BuildImage", ctx, container.Image, build).Return(io.NopCloser(strings.NewReader("")), nil)
+	s.actions.On("InspectContainer", ctx, container.Name).Return(types.ContainerJSON{}, errdefs.NotFound(errors.New("not found")))
+	s.actions.On("CreateContainerWithNetwork", ctx, container).Return("containerID", nil)
+	s.actions.On("StartContainer", ctx, "containerID").Return(nil)
+
+	// Act
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{PullPolicy: docker.PullPolicyAlways})
+
+	// Assert
+	s.NoError(err)
+	s.actions.AssertNotCalled(s.T(), "CheckIfImageExists", ctx, container.Image)
+	s.actions.AssertNotCalled(s.T(), "PullImage", ctx, container.Image)
+}
+
+func (s *clientTestSuite) TestServiceProvisioning_WhenNetworkExists_ThenNetworkIsReused() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "name", Image: "image", Networks: []docker.Network{{Name: "network"}}}
+	containerID := "containerID"
+
+	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(true, nil)
+	s.actions.On("InspectNetwork", ctx, "network").Return(types.NetworkResource{ID: "networkID"}, nil)
+	s.actions.On("InspectContainer", ctx, container.Name).Return(types.ContainerJSON{}, errdefs.NotFound(errors.New("not found")))
+	s.actions.On("CreateContainerWithNetwork", ctx, container).Return(containerID, nil)
+	s.actions.On("StartContainer", ctx, "containerID").Return(nil)
+
+	// Act
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{})
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *clientTestSuite) TestServiceProvisioning_WhenExternalNetworkDoesNotExist_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "name", Image: "image", Networks: []docker.Network{{Name: "network", External: true}}}
 
 	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(true, nil)
-	s.actions.On("CreateNetwork", ctx, networkName).Return(networkID, nil)
-	s.actions.On("CreateContainerWithNetwork", ctx, container.Image, container.Name, networkID, container.EnvironmentVars).Return("", errors.New("error"))
+	s.actions.On("InspectNetwork", ctx, "network").Return(types.NetworkResource{}, errdefs.NotFound(errors.New("not found")))
 
 	// Act
-	err := s.sut.ServiceProvisioning(ctx, container)
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{})
 
 	// Assert
 	s.Error(err)
 }
 
-func (s *clientTestSuite) TestServiceProvisioning_WhenErrorOccursOnStartingContainer_ThenFailure() {
+func (s *clientTestSuite) TestServiceProvisioning_WhenContainerIsStopped_ThenItIsStartedAgain() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "name", Image: "image", EnvironmentVars: []string{"KEY=value"}}
+	existing := newExistingContainerJSON("containerID", container.ComposeLabels(), false)
+	existing.Image = "imageID"
+
+	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(true, nil)
+	s.actions.On("InspectContainer", ctx, container.Name).Return(existing, nil)
+	s.actions.On("InspectImage", ctx, container.Image).Return("imageID", nil)
+	s.actions.On("StartContainer", ctx, "containerID").Return(nil)
+
+	// Act
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{})
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *clientTestSuite) TestServiceProvisioning_WhenContainerIsRunning_ThenItIsLeftAsIs() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "name", Image: "image", Networks: []docker.Network{{Name: "network"}}}
+	existing := newExistingContainerJSON("containerID", container.ComposeLabels(), true)
+	existing.Image = "imageID"
+
+	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(true, nil)
+	s.actions.On("InspectNetwork", ctx, "network").Return(types.NetworkResource{ID: "networkID"}, nil)
+	s.actions.On("InspectContainer", ctx, container.Name).Return(existing, nil)
+	s.actions.On("InspectImage", ctx, container.Image).Return("imageID", nil)
+
+	// Act
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{})
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *clientTestSuite) TestServiceProvisioning_WhenConfigurationChanged_ThenContainerIsRecreated() {
+	// Arrange
+	ctx := context.Background()
+	previous := docker.Container{Name: "name", Image: "image:1"}
+	container := docker.Container{Name: "name", Image: "image:2"}
+	existing := newExistingContainerJSON("oldID", previous.ComposeLabels(), true)
+
+	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(true, nil)
+	s.actions.On("InspectContainer", ctx, container.Name).Return(existing, nil)
+	s.actions.On("StopContainer", ctx, container).Return("oldID", nil)
+	s.actions.On("RemoveContainer", ctx, "oldID").Return(nil)
+	s.actions.On("CreateContainerWithNetwork", ctx, container).Return("newID", nil)
+	s.actions.On("StartContainer", ctx, "newID").Return(nil)
+
+	// Act
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{})
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *clientTestSuite) TestServiceProvisioning_WhenImageIsRebuilt_ThenContainerIsRecreated() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "name", Image: "image", Build: &docker.Build{Context: "/project"}, PullPolicy: docker.PullPolicyBuild}
+	existing := newExistingContainerJSON("oldID", container.ComposeLabels(), true)
+	existing.Image = "oldImageID"
+
+	s.actions.On("BuildImage", ctx, container.Image, *container.Build).Return(io.NopCloser(strings.NewReader(`{"stream":"Successfully built"}`)), nil)
+	s.actions.On("InspectContainer", ctx, container.Name).Return(existing, nil)
+	s.actions.On("InspectImage", ctx, container.Image).Return("newImageID", nil)
+	s.actions.On("StopContainer", ctx, container).Return("oldID", nil)
+	s.actions.On("RemoveContainer", ctx, "oldID").Return(nil)
+	s.actions.On("CreateContainerWithNetwork", ctx, container).Return("newID", nil)
+	s.actions.On("StartContainer", ctx, "newID").Return(nil)
+
+	// Act
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{})
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *clientTestSuite) TestServiceProvisioning_WhenErrorOccursOnInspectingImage_ThenFailure() {
 	// Arrange
 	ctx := context.Background()
 	container := docker.Container{Name: "name", Image: "image"}
-	networkID := "networkID"
-	containerID := "containerID"
-	networkName := fmt.Sprintf("%s-network", container.Name)
+	existing := newExistingContainerJSON("containerID", container.ComposeLabels(), false)
 
 	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(true, nil)
-	s.actions.On("CreateNetwork", ctx, networkName).Return(networkID, nil)
-	s.actions.On("CreateContainerWithNetwork", ctx, container.Image, container.Name, networkID, container.EnvironmentVars).Return(containerID, nil)
-	s.actions.On("StartContainer", ctx, "containerID").Return(errors.New("error"))
+	s.actions.On("InspectContainer", ctx, container.Name).Return(existing, nil)
+	s.actions.On("InspectImage", ctx, container.Image).Return("", errors.New("error"))
 
 	// Act
-	err := s.sut.ServiceProvisioning(ctx, container)
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{})
 
 	// Assert
 	s.Error(err)
 }
 
-func (s *clientTestSuite) TestServiceDecommissioning_WhenContainerExists_ThenSuccess() {
+func (s *clientTestSuite) TestServiceProvisioning_WhenConfigHashIsMissing_ThenStoppedContainerIsRecreated() {
 	// Arrange
 	ctx := context.Background()
-	container := docker.Container{Name: "name", Image: "image"}
-	containerID := "containerID"
+	container := docker.Container{Name: "project-name", Project: "project", Service: "name", Image: "image"}
+	existing := newExistingContainerJSON("oldID", map[string]string{docker.LabelProject: "project", docker.LabelService: "name"}, false)
 
-	s.actions.On("StopContainer", ctx, container.Name).Return(containerID, nil)
-	s.actions.On("RemoveContainer", ctx, containerID).Return(nil)
-	s.actions.On("RemoveNetwork", ctx, container.Name).Return(nil)
+	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(true, nil)
+	s.actions.On("InspectContainer", ctx, container.Name).Return(existing, nil)
+	s.actions.On("RemoveContainer", ctx, "oldID").Return(nil)
+	s.actions.On("CreateContainerWithNetwork", ctx, container).Return("newID", nil)
+	s.actions.On("StartContainer", ctx, "newID").Return(nil)
 
 	// Act
-	err := s.sut.ServiceDecommissioning(ctx, container)
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{})
 
 	// Assert
 	s.NoError(err)
 }
 
-func (s *clientTestSuite) TestServiceDecommissioning_WhenDoesNotExists_ThenSuccess() {
+func (s *clientTestSuite) TestServiceProvisioning_WhenForceRecreate_ThenUnchangedContainerIsRecreated() {
 	// Arrange
 	ctx := context.Background()
 	container := docker.Container{Name: "name", Image: "image"}
+	existing := newExistingContainerJSON("oldID", container.ComposeLabels(), true)
 
-	s.actions.On("StopContainer", ctx, container.Name).Return("", nil)
+	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(true, nil)
+	s.actions.On("InspectContainer", ctx, container.Name).Return(existing, nil)
+	s.actions.On("StopContainer", ctx, container).Return("oldID", nil)
+	s.actions.On("RemoveContainer", ctx, "oldID").Return(nil)
+	s.actions.On("CreateContainerWithNetwork", ctx, container).Return("newID", nil)
+	s.actions.On("StartContainer", ctx, "newID").Return(nil)
 
 	// Act
-	err := s.sut.ServiceDecommissioning(ctx, container)
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{ForceRecreate: true})
 
 	// Assert
 	s.NoError(err)
 }
 
-func (s *clientTestSuite) TestServiceDecommissioning_WhenErrorOccursOnStoppingContainer_ThenFailure() {
+func (s *clientTestSuite) TestServiceProvisioning_WhenNoRecreate_ThenChangedContainerIsStarted() {
 	// Arrange
 	ctx := context.Background()
 	container := docker.Container{Name: "name", Image: "image"}
+	existing := newExistingContainerJSON("containerID", nil, false)
 
-	s.actions.On("StopContainer", ctx, container.Name).Return("", errors.New("error"))
+	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(true, nil)
+	s.actions.On("InspectContainer", ctx, container.Name).Return(existing, nil)
+	s.actions.On("StartContainer", ctx, "containerID").Return(nil)
 
 	// Act
-	err := s.sut.ServiceDecommissioning(ctx, container)
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{NoRecreate: true})
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *clientTestSuite) TestServiceProvisioning_WhenNameIsUsedByAnotherProject_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "project-db", Project: "project", Service: "db", Image: "image"}
+	existing := newExistingContainerJSON("otherID", map[string]string{docker.LabelProject: "other", docker.LabelService: "db"}, true)
+
+	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(true, nil)
+	s.actions.On("InspectContainer", ctx, container.Name).Return(existing, nil)
+
+	// Act
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{ForceRecreate: true})
+
+	// Assert
+	s.Require().Error(err)
+	s.Equal("container name project-db is in use by a container not owned by project project", err.Error())
+	s.actions.AssertNotCalled(s.T(), "StopContainer", mock.Anything, mock.Anything)
+	s.actions.AssertNotCalled(s.T(), "RemoveContainer", mock.Anything, mock.Anything)
+}
+
+func (s *clientTestSuite) TestServiceProvisioning_WhenNameIsUsedByUnlabelledContainer_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "project-db", Project: "project", Service: "db", Image: "image"}
+	existing := newExistingContainerJSON("otherID", nil, false)
+
+	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(true, nil)
+	s.actions.On("InspectContainer", ctx, container.Name).Return(existing, nil)
+
+	// Act
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{})
 
 	// Assert
 	s.Error(err)
+	s.actions.AssertNotCalled(s.T(), "RemoveContainer", mock.Anything, mock.Anything)
 }
 
-func (s *clientTestSuite) TestServiceDecommissioning_WhenErrorOccursOnRemovingContainer_ThenFailure() {
+func (s *clientTestSuite) TestServiceProvisioning_WhenErrorOccursOnInspectingContainer_ThenFailure() {
 	// Arrange
 	ctx := context.Background()
 	container := docker.Container{Name: "name", Image: "image"}
+
+	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(true, nil)
+	s.actions.On("InspectContainer", ctx, container.Name).Return(types.ContainerJSON{}, errors.New("error"))
+
+	// Act
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{})
+
+	// Assert
+	s.Error(err)
+}
+
+func (s *clientTestSuite) TestServiceProvisioning_WhenVolumesAreMounted_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+	bindSource := filepath.Join(s.T().TempDir(), "config")
+	container := docker.Container{
+		Name:  "name",
+		Image: "image",
+		Mounts: []docker.Mount{
+			{Type: "volume", Source: "data", Target: "/data"},
+			{Type: "volume", Source: "shared", Target: "/shared"},
+			{Type: "bind", Source: bindSource, Target: "/config"},
+		},
+		Volumes: []docker.Volume{{Name: "data"}, {Name: "shared", External: true}},
+	}
 	containerID := "containerID"
 
-	s.actions.On("StopContainer", ctx, container.Name).Return(containerID, nil)
-	s.actions.On("RemoveContainer", ctx, containerID).Return(errors.New("error"))
+	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(true, nil)
+	s.actions.On("CreateVolume", ctx, docker.Volume{Name: "data"}).Return(nil)
+	s.actions.On("InspectContainer", ctx, container.Name).Return(types.ContainerJSON{}, errdefs.NotFound(errors.New("not found")))
+	s.actions.On("CreateContainerWithNetwork", ctx, container).Return(containerID, nil)
+	s.actions.On("StartContainer", ctx, "containerID").Return(nil)
 
 	// Act
-	err := s.sut.ServiceDecommissioning(ctx, container)
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{})
+
+	// Assert
+	s.NoError(err)
+	s.DirExists(bindSource)
+}
+
+func (s *clientTestSuite) TestServiceProvisioning_WhenErrorOccursOnCreatingVolume_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "name", Image: "image", Volumes: []docker.Volume{{Name: "data"}}}
+
+	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(true, nil)
+	s.actions.On("CreateVolume", ctx, docker.Volume{Name: "data"}).Return(errors.New("error"))
+
+	// Act
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{})
 
 	// Assert
 	s.Error(err)
 }
 
-func (s *clientTestSuite) TestServiceDecommissioning_WhenErrorOccursOnRemovingNetwork_ThenFailure() {
+func (s *clientTestSuite) TestServiceProvisioning_WhenErrorOccursOnCheckingImageExists_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "name", Image: "image", Networks: []docker.Network{{Name: "network"}}}
+
+	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(false, errors.New("error"))
+
+	// Act
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{})
+
+	// Assert
+	s.Error(err)
+}
+
+func (s *clientTestSuite) TestServiceProvisioning_WhenErrorOccursOnPullingImage_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "name", Image: "image", Networks: []docker.Network{{Name: "network"}}}
+
+	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(false, nil)
+	s.actions.On("PullImage", ctx, container.Image).Return(nil, errors.New("error"))
+
+	// Act
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{})
+
+	// Assert
+	s.Error(err)
+}
+
+func (s *clientTestSuite) TestServiceProvisioning_WhenPullReportsAnError_ThenFailure() {
 	// Arrange
 	ctx := context.Background()
 	container := docker.Container{Name: "name", Image: "image"}
-	containerID := "containerID"
+	progress := `{"status":"Pulling from library/image"}` + "\n" + `{"error":"manifest unknown","errorDetail":{"message":"manifest unknown"}}`
 
-	s.actions.On("StopContainer", ctx, container.Name).Return(containerID, nil)
-	s.actions.On("RemoveContainer", ctx, containerID).Return(nil)
-	s.actions.On("RemoveNetwork", ctx, container.Name).Return(errors.New("error"))
+	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(false, nil)
+	s.actions.On("PullImage", ctx, container.Image).Return(io.NopCloser(strings.NewReader(progress)), nil)
 
 	// Act
-	err := s.sut.ServiceDecommissioning(ctx, container)
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{})
+
+	// Assert
+	s.Require().Error(err)
+	s.Equal("error pulling image image: manifest unknown", err.Error())
+}
+
+func (s *clientTestSuite) TestServiceProvisioning_WhenImageIsPulledAgain_ThenContainerIsRecreated() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "name", Image: "image:latest"}
+	existing := newExistingContainerJSON("oldID", container.ComposeLabels(), false)
+	existing.Image = "oldImageID"
+
+	s.actions.On("PullImage", ctx, container.Image).Return(pullProgress, nil)
+	s.actions.On("InspectContainer", ctx, container.Name).Return(existing, nil)
+	s.actions.On("InspectImage", ctx, container.Image).Return("newImageID", nil)
+	s.actions.On("RemoveContainer", ctx, "oldID").Return(nil)
+	s.actions.On("CreateContainerWithNetwork", ctx, container).Return("newID", nil)
+	s.actions.On("StartContainer", ctx, "newID").Return(nil)
+
+	// Act
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{PullPolicy: docker.PullPolicyAlways})
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *clientTestSuite) TestServiceProvisioning_WhenErrorOccursOnCreationOfNetwork_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "name", Image: "image", Networks: []docker.Network{{Name: "network"}}}
+
+	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(true, nil)
+	s.actions.On("InspectNetwork", ctx, "network").Return(types.NetworkResource{}, errdefs.NotFound(errors.New("not found")))
+	s.actions.On("CreateNetwork", ctx, container.Networks[0]).Return("", errors.New("error"))
+
+	// Act
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{})
+
+	// Assert
+	s.Error(err)
+}
+
+func (s *clientTestSuite) TestServiceProvisioning_WhenErrorOccursOnCreatingContainerWithNetwork_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "name", Image: "image", Networks: []docker.Network{{Name: "network"}}}
+	networkID := "networkID"
+
+	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(true, nil)
+	s.actions.On("InspectNetwork", ctx, "network").Return(types.NetworkResource{}, errdefs.NotFound(errors.New("not found")))
+	s.actions.On("CreateNetwork", ctx, container.Networks[0]).Return(networkID, nil)
+	s.actions.On("InspectContainer", ctx, container.Name).Return(types.ContainerJSON{}, errdefs.NotFound(errors.New("not found")))
+	s.actions.On("CreateContainerWithNetwork", ctx, container).Return("", errors.New("error"))
+
+	// Act
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{})
 
 	// Assert
 	s.Error(err)
 }
+
+func (s *clientTestSuite) TestServiceProvisioning_WhenErrorOccursOnStartingContainer_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "name", Image: "image", Networks: []docker.Network{{Name: "network"}}}
+	networkID := "networkID"
+	containerID := "containerID"
+
+	s.actions.On("CheckIfImageExists", ctx, container.Image).Return(true, nil)
+	s.actions.On("InspectNetwork", ctx, "network").Return(types.NetworkResource{}, errdefs.NotFound(errors.New("not found")))
+	s.actions.On("CreateNetwork", ctx, container.Networks[0]).Return(networkID, nil)
+	s.actions.On("InspectContainer", ctx, container.Name).Return(types.ContainerJSON{}, errdefs.NotFound(errors.New("not found")))
+	s.actions.On("CreateContainerWithNetwork", ctx, container).Return(containerID, nil)
+	s.actions.On("StartContainer", ctx, "containerID").Return(errors.New("error"))
+
+	// Act
+	err := s.sut.ServiceProvisioning(ctx, container, docker.ProvisioningOptions{})
+
+	// Assert
+	s.Error(err)
+}
+
+func (s *clientTestSuite) TestServiceDecommissioning_WhenContainerExists_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "name", Image: "image", Networks: []docker.Network{{Name: "network"}}}
+	containerID := "containerID"
+
+	s.actions.On("StopContainer", ctx, container).Return(containerID, nil)
+	s.actions.On("RemoveContainer", ctx, containerID).Return(nil)
+	s.actions.On("InspectNetwork", ctx, "network").Return(types.NetworkResource{}, nil)
+	s.actions.On("RemoveNetwork", ctx, container.Networks[0]).Return(nil)
+
+	// Act
+	err := s.sut.ServiceDecommissioning(ctx, container)
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *clientTestSuite) TestServiceDecommissioning_WhenNetworkIsStillInUse_ThenNetworkIsKept() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "name", Image: "image", Networks: []docker.Network{{Name: "network"}}}
+	containerID := "containerID"
+
+	networkResource := types.NetworkResource{Containers: map[string]types.EndpointResource{"otherID": {Name: "other"}}}
+	s.actions.On("StopContainer", ctx, container).Return(containerID, nil)
+	s.actions.On("RemoveContainer", ctx, containerID).Return(nil)
+	s.actions.On("InspectNetwork", ctx, "network").Return(networkResource, nil)
+
+	// Act
+	err := s.sut.ServiceDecommissioning(ctx, container)
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *clientTestSuite) TestServiceDecommissioning_WhenDoesNotExists_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "name", Image: "image", Networks: []docker.Network{{Name: "network"}}}
+
+	s.actions.On("StopContainer", ctx, container).Return("", nil)
+
+	// Act
+	err := s.sut.ServiceDecommissioning(ctx, container)
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *clientTestSuite) TestServiceDecommissioning_WhenErrorOccursOnStoppingContainer_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "name", Image: "image", Networks: []docker.Network{{Name: "network"}}}
+
+	s.actions.On("StopContainer", ctx, container).Return("", errors.New("error"))
+
+	// Act
+	err := s.sut.ServiceDecommissioning(ctx, container)
+
+	// Assert
+	s.Error(err)
+}
+
+func (s *clientTestSuite) TestServiceDecommissioning_WhenErrorOccursOnRemovingContainer_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "name", Image: "image", Networks: []docker.Network{{Name: "network"}}}
+	containerID := "containerID"
+
+	s.actions.On("StopContainer", ctx, container).Return(containerID, nil)
+	s.actions.On("RemoveContainer", ctx, containerID).Return(errors.New("error"))
+
+	// Act
+	err := s.sut.ServiceDecommissioning(ctx, container)
+
+	// Assert
+	s.Error(err)
+}
+
+func (s *clientTestSuite) TestServiceDecommissioning_WhenNetworkIsNotOwned_ThenNetworkIsKept() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "name", Networks: []docker.Network{{Name: "network"}}}
+	containerID := "containerID"
+
+	s.actions.On("StopContainer", ctx, container).Return(containerID, nil)
+	s.actions.On("RemoveContainer", ctx, containerID).Return(nil)
+	s.actions.On("InspectNetwork", ctx, "network").Return(types.NetworkResource{}, nil)
+	s.actions.On("RemoveNetwork", ctx, container.Networks[0]).Return(errdefs.NotFound(errors.New("not found")))
+
+	// Act
+	err := s.sut.ServiceDecommissioning(ctx, container)
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *clientTestSuite) TestServiceDecommissioning_WhenErrorOccursOnRemovingNetwork_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "name", Image: "image", Networks: []docker.Network{{Name: "network"}}}
+	containerID := "containerID"
+
+	s.actions.On("StopContainer", ctx, container).Return(containerID, nil)
+	s.actions.On("RemoveContainer", ctx, containerID).Return(nil)
+	s.actions.On("InspectNetwork", ctx, "network").Return(types.NetworkResource{}, nil)
+	s.actions.On("RemoveNetwork", ctx, container.Networks[0]).Return(errors.New("error"))
+
+	// Act
+	err := s.sut.ServiceDecommissioning(ctx, container)
+
+	// Assert
+	s.Error(err)
+}
+
+func (s *clientTestSuite) TestRestartService_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "name"}
+	existing := newExistingContainerJSON("containerID", nil, true)
+
+	s.actions.On("InspectContainer", ctx, container.Name).Return(existing, nil)
+	s.actions.On("RestartContainer", ctx, "containerID", 5*time.Second).Return(nil)
+
+	// Act
+	err := s.sut.RestartService(ctx, container, 5*time.Second)
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *clientTestSuite) TestRestartService_WhenNameIsUsedByAnotherProject_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "project-db", Project: "project", Service: "db"}
+	existing := newExistingContainerJSON("otherID", map[string]string{docker.LabelProject: "other", docker.LabelService: "db"}, true)
+
+	s.actions.On("InspectContainer", ctx, container.Name).Return(existing, nil)
+
+	// Act
+	err := s.sut.RestartService(ctx, container, 5*time.Second)
+
+	// Assert
+	s.Error(err)
+	s.actions.AssertNotCalled(s.T(), "RestartContainer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *clientTestSuite) TestRestartService_WhenContainerNotFound_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "project-db", Service: "db"}
+
+	s.actions.On("InspectContainer", ctx, container.Name).Return(types.ContainerJSON{}, errdefs.NotFound(errors.New("not found")))
+
+	// Act
+	err := s.sut.RestartService(ctx, container, 5*time.Second)
+
+	// Assert
+	s.Require().Error(err)
+	s.Equal("container project-db of service db does not exist, run start to create it", err.Error())
+}
+
+func (s *clientTestSuite) TestRestartService_WhenErrorOccursOnRestarting_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "name"}
+	existing := newExistingContainerJSON("containerID", nil, true)
+
+	s.actions.On("InspectContainer", ctx, container.Name).Return(existing, nil)
+	s.actions.On("RestartContainer", ctx, "containerID", 5*time.Second).Return(errors.New("error"))
+
+	// Act
+	err := s.sut.RestartService(ctx, container, 5*time.Second)
+
+	// Assert
+	s.Error(err)
+}
+
+func (s *clientTestSuite) TestWaitForServices_WhenContainersAreHealthy_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+
+	healthy := newContainerJSON(&types.ContainerState{Status: "running", Running: true, Health: &types.Health{Status: types.Healthy}})
+	withoutHealthcheck := newContainerJSON(&types.ContainerState{Status: "running", Running: true})
+	s.actions.On("InspectContainer", mock.Anything, "db").Return(healthy, nil)
+	s.actions.On("InspectContainer", mock.Anything, "cache").Return(withoutHealthcheck, nil)
+
+	// Act
+	err := s.sut.WaitForServices(ctx, []string{"db", "cache"}, docker.ConditionHealthy, time.Minute)
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *clientTestSuite) TestWaitForServices_WhenContainerCompletedSuccessfully_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+
+	exited := newContainerJSON(&types.ContainerState{Status: "exited"})
+	s.actions.On("InspectContainer", mock.Anything, "migrate").Return(exited, nil)
+
+	// Act
+	err := s.sut.WaitForServices(ctx, []string{"migrate"}, docker.ConditionCompleted, time.Minute)
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *clientTestSuite) TestWaitForServices_WhenContainerExitedWithError_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+
+	exited := newContainerJSON(&types.ContainerState{Status: "exited", ExitCode: 1})
+	s.actions.On("InspectContainer", mock.Anything, "migrate").Return(exited, nil)
+
+	// Act
+	err := s.sut.WaitForServices(ctx, []string{"migrate"}, docker.ConditionCompleted, time.Minute)
+
+	// Assert
+	s.EqualError(err, "containers did not satisfy condition service_completed_successfully: migrate (exited with code 1)")
+}
+
+func (s *clientTestSuite) TestWaitForServices_WhenContainersDoNotBecomeHealthy_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+
+	unhealthy := newContainerJSON(&types.ContainerState{Status: "running", Running: true, Health: &types.Health{Status: types.Unhealthy}})
+	starting := newContainerJSON(&types.ContainerState{Status: "running", Running: true, Health: &types.Health{Status: types.Starting}})
+	s.actions.On("InspectContainer", mock.Anything, "db").Return(unhealthy, nil)
+	s.actions.On("InspectContainer", mock.Anything, "cache").Return(starting, nil)
+
+	// Act
+	err := s.sut.WaitForServices(ctx, []string{"db", "cache"}, docker.ConditionHealthy, 10*time.Millisecond)
+
+	// Assert
+	s.EqualError(err, "containers did not satisfy condition service_healthy: db (container is unhealthy), cache (timed out)")
+}
+
+func (s *clientTestSuite) TestWaitForServices_WhenErrorOccursOnInspectingContainer_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+
+	s.actions.On("InspectContainer", mock.Anything, "db").Return(types.ContainerJSON{}, errors.New("error"))
+
+	// Act
+	err := s.sut.WaitForServices(ctx, []string{"db"}, docker.ConditionHealthy, time.Minute)
+
+	// Assert
+	s.Error(err)
+}
+
+func (s *clientTestSuite) TestListServices_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+	containers := []docker.Container{{Name: "db", Service: "db"}, {Name: "cache", Service: "cache"}, {Name: "nginx", Service: "nginx"}}
+
+	dockerContainers := []types.Container{
+		{
+			ID:    "dbID",
+			Names: []string{"/db"},
+			Image: "mysql:latest",
+			State: "running",
+			Ports: []types.Port{{IP: "0.0.0.0", PrivatePort: 3306, PublicPort: 3306, Type: "tcp"}, {PrivatePort: 33060, Type: "tcp"}},
+			NetworkSettings: &types.SummaryNetworkSettings{
+				Networks: map[string]*network.EndpointSettings{"project_default": {}},
+			},
+		},
+		{ID: "cacheID", Names: []string{"/cache"}, Image: "memcached", State: "exited"},
+	}
+	startedAt := time.Now().Add(-3 * time.Hour).Format(time.RFC3339Nano)
+	running := newContainerJSON(&types.ContainerState{Running: true, StartedAt: startedAt, Health: &types.Health{Status: types.Healthy}})
+	exited := newContainerJSON(&types.ContainerState{Status: "exited"})
+
+	s.actions.On("ListContainers", ctx, []string{"db", "cache", "nginx"}, true).Return(dockerContainers, nil)
+	s.actions.On("InspectContainer", ctx, "dbID").Return(running, nil)
+	s.actions.On("InspectContainer", ctx, "cacheID").Return(exited, nil)
+
+	// Act
+	result, err := s.sut.ListServices(ctx, containers, true)
+
+	// Assert
+	want := []docker.ServiceStatus{
+		{
+			Service:     "db",
+			ContainerID: "dbID",
+			Image:       "mysql:latest",
+			State:       "running",
+			Health:      "healthy",
+			Uptime:      "3 hours",
+			Ports:       []string{"0.0.0.0:3306->3306/tcp", "33060/tcp"},
+			Networks:    []string{"project_default"},
+		},
+		{Service: "cache", ContainerID: "cacheID", Image: "memcached", State: "exited"},
+	}
+
+	s.NoError(err)
+	s.Equal(want, result)
+}
+
+func (s *clientTestSuite) TestListServices_WhenErrorOccursOnListingContainers_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	containers := []docker.Container{{Name: "db", Service: "db"}}
+
+	s.actions.On("ListContainers", ctx, []string{"db"}, false).Return(nil, errors.New("error"))
+
+	// Act
+	result, err := s.sut.ListServices(ctx, containers, false)
+
+	// Assert
+	s.Error(err)
+	s.Empty(result)
+}
+
+func (s *clientTestSuite) TestStreamLogs_ThenLinesArePrefixed() {
+	// Arrange
+	ctx := context.Background()
+	containers := []docker.Container{{Name: "db", Service: "db"}, {Name: "cache", Service: "cache"}}
+	options := docker.LogOptions{Tail: "all"}
+
+	var dbLogs bytes.Buffer
+	_, _ = stdcopy.NewStdWriter(&dbLogs, stdcopy.Stdout).Write([]byte("ready for connections\n"))
+	_, _ = stdcopy.NewStdWriter(&dbLogs, stdcopy.Stderr).Write([]byte("warning\npartial"))
+	ttyContainer := types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{}, Config: &container.Config{Tty: true}}
+
+	s.actions.On("InspectContainer", ctx, "db").Return(newContainerJSON(&types.ContainerState{}), nil)
+	s.actions.On("ContainerLogs", ctx, "db", options).Return(io.NopCloser(&dbLogs), nil)
+	s.actions.On("InspectContainer", ctx, "cache").Return(ttyContainer, nil)
+	s.actions.On("ContainerLogs", ctx, "cache", options).Return(io.NopCloser(strings.NewReader("started\n")), nil)
+
+	var out bytes.Buffer
+
+	// Act
+	err := s.sut.StreamLogs(ctx, containers, options, &out)
+
+	// Assert
+	s.NoError(err)
+	s.ElementsMatch([]string{
+		"db    | ready for connections",
+		"db    | warning",
+		"db    | partial",
+		"cache | started",
+	}, strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n"))
+}
+
+func (s *clientTestSuite) TestStreamLogs_WhenContainerNotFound_ThenItIsSkipped() {
+	// Arrange
+	ctx := context.Background()
+	containers := []docker.Container{{Name: "db", Service: "db"}}
+
+	s.actions.On("InspectContainer", ctx, "db").Return(types.ContainerJSON{}, errdefs.NotFound(errors.New("not found")))
+
+	var out bytes.Buffer
+
+	// Act
+	err := s.sut.StreamLogs(ctx, containers, docker.LogOptions{}, &out)
+
+	// Assert
+	s.NoError(err)
+	s.Empty(out.String())
+}
+
+func (s *clientTestSuite) TestStreamLogs_WhenContextIsCancelled_ThenSuccess() {
+	// Arrange
+	ctx := context.Background()
+	containers := []docker.Container{{Name: "db", Service: "db"}}
+
+	s.actions.On("InspectContainer", ctx, "db").Return(newContainerJSON(&types.ContainerState{}), nil)
+	s.actions.On("ContainerLogs", ctx, "db", docker.LogOptions{}).Return(nil, context.Canceled)
+
+	// Act
+	err := s.sut.StreamLogs(ctx, containers, docker.LogOptions{}, io.Discard)
+
+	// Assert
+	s.NoError(err)
+}
+
+func (s *clientTestSuite) TestStreamLogs_WhenErrorOccursOnReadingLogs_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	containers := []docker.Container{{Name: "db", Service: "db"}, {Name: "cache", Service: "cache"}}
+
+	s.actions.On("InspectContainer", ctx, "db").Return(newContainerJSON(&types.ContainerState{}), nil)
+	s.actions.On("ContainerLogs", ctx, "db", docker.LogOptions{}).Return(nil, errors.New("error"))
+	s.actions.On("InspectContainer", ctx, "cache").Return(types.ContainerJSON{}, errors.New("error"))
+
+	// Act
+	err := s.sut.StreamLogs(ctx, containers, docker.LogOptions{}, io.Discard)
+
+	// Assert
+	s.EqualError(err, "reading logs failed: cache (error), db (error)")
+}
+
+func (s *clientTestSuite) TestExecService_WhenNotInteractive_ThenOutputIsDemultiplexed() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "db", Service: "db"}
+	options := docker.ExecOptions{Command: []string{"mysql", "--version"}}
+
+	var output bytes.Buffer
+	_, _ = stdcopy.NewStdWriter(&output, stdcopy.Stdout).Write([]byte("mysql 8.0\n"))
+	_, _ = stdcopy.NewStdWriter(&output, stdcopy.Stderr).Write([]byte("warning\n"))
+	response := newHijackedResponse(&output)
+
+	s.actions.On("CreateExec", ctx, "db", options).Return("execID", nil)
+	s.actions.On("AttachExec", ctx, "execID", false).Return(response, nil)
+	s.actions.On("InspectExec", ctx, "execID").Return(2, nil)
+
+	var stdout, stderr bytes.Buffer
+
+	// Act
+	exitCode, err := s.sut.ExecService(ctx, container, options, docker.ExecStreams{Out: &stdout, Err: &stderr})
+
+	// Assert
+	s.NoError(err)
+	s.Equal(2, exitCode)
+	s.Equal("mysql 8.0\n", stdout.String())
+	s.Equal("warning\n", stderr.String())
+}
+
+func (s *clientTestSuite) TestExecService_WhenTty_ThenInputIsForwardedAndTerminalResized() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "db", Service: "db"}
+	options := docker.ExecOptions{Command: []string{"sh"}, Tty: true, Interactive: true}
+
+	clientConn, serverConn := net.Pipe()
+	response := types.HijackedResponse{Conn: clientConn, Reader: bufio.NewReader(clientConn)}
+	resize := make(chan docker.TerminalSize, 1)
+	resize <- docker.TerminalSize{Height: 40, Width: 120}
+	resized := make(chan struct{})
+
+	s.actions.On("CreateExec", ctx, "db", options).Return("execID", nil)
+	s.actions.On("AttachExec", ctx, "execID", true).Return(response, nil)
+	s.actions.On("ResizeExec", ctx, "execID", docker.TerminalSize{Height: 40, Width: 120}).
+		Run(func(mock.Arguments) { close(resized) }).Return(nil)
+	s.actions.On("InspectExec", ctx, "execID").Return(0, nil)
+
+	// The server side echoes the input back and then closes the session.
+	go func() {
+		<-resized
+		buf := make([]byte, len("exit\n"))
+		_, _ = io.ReadFull(serverConn, buf)
+		_, _ = serverConn.Write(buf)
+		_ = serverConn.Close()
+	}()
+
+	var stdout bytes.Buffer
+	streams := docker.ExecStreams{In: strings.NewReader("exit\n"), Out: &stdout, Resize: resize}
+
+	// Act
+	exitCode, err := s.sut.ExecService(ctx, container, options, streams)
+
+	// Assert
+	s.NoError(err)
+	s.Zero(exitCode)
+	s.Equal("exit\n", stdout.String())
+}
+
+func (s *clientTestSuite) TestExecService_WhenErrorOccursOnCreatingExec_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "db", Service: "db"}
+	options := docker.ExecOptions{Command: []string{"ls"}}
+
+	s.actions.On("CreateExec", ctx, "db", options).Return("", errors.New("error"))
+
+	// Act
+	exitCode, err := s.sut.ExecService(ctx, container, options, docker.ExecStreams{})
+
+	// Assert
+	s.Error(err)
+	s.Zero(exitCode)
+}
+
+func (s *clientTestSuite) TestExecService_WhenErrorOccursOnAttachingExec_ThenFailure() {
+	// Arrange
+	ctx := context.Background()
+	container := docker.Container{Name: "db", Service: "db"}
+	options := docker.ExecOptions{Command: []string{"ls"}}
+
+	s.actions.On("CreateExec", ctx, "db", options).Return("execID", nil)
+	s.actions.On("AttachExec", ctx, "execID", false).Return(types.HijackedResponse{}, errors.New("error"))
+
+	// Act
+	exitCode, err := s.sut.ExecService(ctx, container, options, docker.ExecStreams{})
+
+	// Assert
+	s.Error(err)
+	s.Zero(exitCode)
+}
+
+func newHijackedResponse(output io.Reader) types.HijackedResponse {
+	clientConn, _ := net.Pipe()
+	return types.HijackedResponse{Conn: clientConn, Reader: bufio.NewReader(output)}
+}
+
+func newExistingContainerJSON(id string, labels map[string]string, running bool) types.ContainerJSON {
+	return types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{ID: id, State: &types.ContainerState{Running: running}},
+		Config:            &container.Config{Labels: labels},
+	}
+}
+
+// pullProgress returns the progress messages of a successful pull, a new reader for every call.
+func pullProgress(context.Context, string) io.ReadCloser {
+	return io.NopCloser(strings.NewReader(`{"status":"Pulling fs layer","id":"abc"}` + "\n" + `{"status":"Pull complete","id":"abc"}`))
+}
+
+func newContainerJSON(state *types.ContainerState) types.ContainerJSON {
+	return types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{State: state}}
+}
+
+func (s *clientTestSuite) TestPullImages_ThenEveryImageIsPulledOnce() {
+	// Arrange
+	ctx := context.Background()
+	containers := []docker.Container{
+		{Service: "web", Image: "nginx:alpine"},
+		{Service: "db", Image: "mysql:8"},
+		{Service: "proxy", Image: "nginx:alpine"},
+		{Service: "api", Image: "project-api", Build: &docker.Build{Context: "/project/api"}},
+		{Service: "cache", Image: "redis:7", PullPolicy: docker.PullPolicyNever},
+	}
+
+	s.actions.On("PullImage", ctx, "nginx:alpine").Return(pullProgress, nil).Once()
+	s.actions.On("PullImage", ctx, "mysql:8").Return(io.NopCloser(strings.NewReader(`{"error":"manifest unknown","errorDetail":{"message":"manifest unknown"}}`)), nil).Once()
+
+	// Act
+	results := s.sut.PullImages(ctx, containers, 2)
+
+	// Assert
+	s.Equal([]docker.PullResult{
+		{Image: "mysql:8", Services: []string{"db"}, Err: errors.New("error pulling image mysql:8: manifest unknown")},
+		{Image: "nginx:alpine", Services: []string{"web", "proxy"}},
+		{Image: "project-api", Services: []string{"api"}, Skipped: "built from a Dockerfile"},
+		{Image: "redis:7", Services: []string{"cache"}, Skipped: "pull policy is never"},
+	}, results)
+}
+
+func (s *clientTestSuite) TestPullImages_ThenOnlyStatusChangesAreLoggedWithTheImage() {
+	// Arrange
+	ctx := context.Background()
+	containers := []docker.Container{{Service: "web", Image: "nginx:alpine"}}
+	progress := strings.Join([]string{
+		`{"status":"Pulling from library/nginx","id":"alpine"}`,
+		`{"status":"Pulling fs layer","progressDetail":{},"id":"abc"}`,
+		`{"status":"Downloading","progressDetail":{"current":10,"total":100},"progress":"[=>   ]","id":"abc"}`,
+		`{"status":"Downloading","progressDetail":{"current":50,"total":100},"progress":"[==> ]","id":"abc"}`,
+		`{"status":"Download complete","progressDetail":{},"id":"abc"}`,
+		`{"status":"Extracting","progressDetail":{"current":50,"total":100},"progress":"[==> ]","id":"abc"}`,
+		`{"status":"Pull complete","progressDetail":{},"id":"abc"}`,
+		`{"status":"Pull complete","progressDetail":{},"id":"abc"}`,
+		`{"status":"Status: Downloaded newer image for nginx:alpine"}`,
+	}, "\n")
+
+	var output bytes.Buffer
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+
+	s.actions.On("PullImage", ctx, "nginx:alpine").Return(io.NopCloser(strings.NewReader(progress)), nil).Once()
+
+	// Act
+	results := s.sut.PullImages(ctx, containers, 1)
+
+	// Assert
+	s.Equal([]docker.PullResult{{Image: "nginx:alpine", Services: []string{"web"}}}, results)
+	s.Equal("Pulling image nginx:alpine\n"+
+		"nginx:alpine: alpine: Pulling from library/nginx\n"+
+		"nginx:alpine: abc: Pulling fs layer\n"+
+		"nginx:alpine: abc: Download complete\n"+
+		"nginx:alpine: abc: Pull complete\n"+
+		"nginx:alpine: Status: Downloaded newer image for nginx:alpine\n"+
+		"Successfully pulled image nginx:alpine\n", output.String())
+}
+
+func (s *clientTestSuite) TestPullImages_WhenParallelismIsBounded_ThenPullsDoNotExceedIt() {
+	// Arrange
+	ctx := context.Background()
+	var containers []docker.Container
+	for i := 0; i < 6; i++ {
+		containers = append(containers, docker.Container{Service: fmt.Sprintf("service%d", i), Image: fmt.Sprintf("image%d", i)})
+	}
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	s.actions.On("PullImage", ctx, mock.Anything).
+		Run(func(mock.Arguments) {
+			mu.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}).
+		Return(pullProgress, nil)
+
+	// Act
+	results := s.sut.PullImages(ctx, containers, 2)
+
+	// Assert
+	s.Len(results, 6)
+	s.LessOrEqual(maxRunning, 2)
+	s.actions.AssertNumberOfCalls(s.T(), "PullImage", 6)
+}
@@ -4,7 +4,11 @@ package docker_test
 
 import (
 	context "context"
+	io "io"
+	time "time"
 
+	types "github.com/docker/docker/api/types"
+	docker "github.com/petrovskiborislav/docker-cli/docker"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -13,6 +17,50 @@ type mockActions struct {
 	mock.Mock
 }
 
+// AttachExec provides a mock function with given fields: ctx, execID, tty
+func (_m *mockActions) AttachExec(ctx context.Context, execID string, tty bool) (types.HijackedResponse, error) {
+	ret := _m.Called(ctx, execID, tty)
+
+	var r0 types.HijackedResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) types.HijackedResponse); ok {
+		r0 = rf(ctx, execID, tty)
+	} else {
+		r0 = ret.Get(0).(types.HijackedResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, execID, tty)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BuildImage provides a mock function with given fields: ctx, imageName, build
+func (_m *mockActions) BuildImage(ctx context.Context, imageName string, build docker.Build) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, imageName, build)
+
+	var r0 io.ReadCloser
+	if rf, ok := ret.Get(0).(func(context.Context, string, docker.Build) io.ReadCloser); ok {
+		r0 = rf(ctx, imageName, build)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, docker.Build) error); ok {
+		r1 = rf(ctx, imageName, build)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CheckIfImageExists provides a mock function with given fields: ctx, imageName
 func (_m *mockActions) CheckIfImageExists(ctx context.Context, imageName string) (bool, error) {
 	ret := _m.Called(ctx, imageName)
@@ -34,20 +82,43 @@ func (_m *mockActions) CheckIfImageExists(ctx context.Context, imageName string)
 	return r0, r1
 }
 
-// CreateContainerWithNetwork provides a mock function with given fields: ctx, imageName, containerName, networkID, envs
-func (_m *mockActions) CreateContainerWithNetwork(ctx context.Context, imageName string, containerName string, networkID string, envs []string) (string, error) {
-	ret := _m.Called(ctx, imageName, containerName, networkID, envs)
+// ContainerLogs provides a mock function with given fields: ctx, containerName, options
+func (_m *mockActions) ContainerLogs(ctx context.Context, containerName string, options docker.LogOptions) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, containerName, options)
+
+	var r0 io.ReadCloser
+	if rf, ok := ret.Get(0).(func(context.Context, string, docker.LogOptions) io.ReadCloser); ok {
+		r0 = rf(ctx, containerName, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, docker.LogOptions) error); ok {
+		r1 = rf(ctx, containerName, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateContainerWithNetwork provides a mock function with given fields: ctx, container
+func (_m *mockActions) CreateContainerWithNetwork(ctx context.Context, container docker.Container) (string, error) {
+	ret := _m.Called(ctx, container)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []string) string); ok {
-		r0 = rf(ctx, imageName, containerName, networkID, envs)
+	if rf, ok := ret.Get(0).(func(context.Context, docker.Container) string); ok {
+		r0 = rf(ctx, container)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, []string) error); ok {
-		r1 = rf(ctx, imageName, containerName, networkID, envs)
+	if rf, ok := ret.Get(1).(func(context.Context, docker.Container) error); ok {
+		r1 = rf(ctx, container)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -55,17 +126,136 @@ func (_m *mockActions) CreateContainerWithNetwork(ctx context.Context, imageName
 	return r0, r1
 }
 
-// CreateNetwork provides a mock function with given fields: ctx, networkName
-func (_m *mockActions) CreateNetwork(ctx context.Context, networkName string) (string, error) {
-	ret := _m.Called(ctx, networkName)
+// CreateExec provides a mock function with given fields: ctx, containerName, options
+func (_m *mockActions) CreateExec(ctx context.Context, containerName string, options docker.ExecOptions) (string, error) {
+	ret := _m.Called(ctx, containerName, options)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, docker.ExecOptions) string); ok {
+		r0 = rf(ctx, containerName, options)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, docker.ExecOptions) error); ok {
+		r1 = rf(ctx, containerName, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateNetwork provides a mock function with given fields: ctx, network
+func (_m *mockActions) CreateNetwork(ctx context.Context, network docker.Network) (string, error) {
+	ret := _m.Called(ctx, network)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, docker.Network) string); ok {
+		r0 = rf(ctx, network)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, docker.Network) error); ok {
+		r1 = rf(ctx, network)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateVolume provides a mock function with given fields: ctx, volume
+func (_m *mockActions) CreateVolume(ctx context.Context, volume docker.Volume) error {
+	ret := _m.Called(ctx, volume)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, docker.Volume) error); ok {
+		r0 = rf(ctx, volume)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InspectContainer provides a mock function with given fields: ctx, containerName
+func (_m *mockActions) InspectContainer(ctx context.Context, containerName string) (types.ContainerJSON, error) {
+	ret := _m.Called(ctx, containerName)
+
+	var r0 types.ContainerJSON
+	if rf, ok := ret.Get(0).(func(context.Context, string) types.ContainerJSON); ok {
+		r0 = rf(ctx, containerName)
+	} else {
+		r0 = ret.Get(0).(types.ContainerJSON)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, containerName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InspectExec provides a mock function with given fields: ctx, execID
+func (_m *mockActions) InspectExec(ctx context.Context, execID string) (int, error) {
+	ret := _m.Called(ctx, execID)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, execID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, execID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InspectImage provides a mock function with given fields: ctx, imageName
+func (_m *mockActions) InspectImage(ctx context.Context, imageName string) (string, error) {
+	ret := _m.Called(ctx, imageName)
 
 	var r0 string
 	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = rf(ctx, networkName)
+		r0 = rf(ctx, imageName)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, imageName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InspectNetwork provides a mock function with given fields: ctx, networkName
+func (_m *mockActions) InspectNetwork(ctx context.Context, networkName string) (types.NetworkResource, error) {
+	ret := _m.Called(ctx, networkName)
+
+	var r0 types.NetworkResource
+	if rf, ok := ret.Get(0).(func(context.Context, string) types.NetworkResource); ok {
+		r0 = rf(ctx, networkName)
+	} else {
+		r0 = ret.Get(0).(types.NetworkResource)
+	}
+
 	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
 		r1 = rf(ctx, networkName)
@@ -76,18 +266,50 @@ func (_m *mockActions) CreateNetwork(ctx context.Context, networkName string) (s
 	return r0, r1
 }
 
+// ListContainers provides a mock function with given fields: ctx, containerNames, all
+func (_m *mockActions) ListContainers(ctx context.Context, containerNames []string, all bool) ([]types.Container, error) {
+	ret := _m.Called(ctx, containerNames, all)
+
+	var r0 []types.Container
+	if rf, ok := ret.Get(0).(func(context.Context, []string, bool) []types.Container); ok {
+		r0 = rf(ctx, containerNames, all)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Container)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string, bool) error); ok {
+		r1 = rf(ctx, containerNames, all)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PullImage provides a mock function with given fields: ctx, imageName
-func (_m *mockActions) PullImage(ctx context.Context, imageName string) error {
+func (_m *mockActions) PullImage(ctx context.Context, imageName string) (io.ReadCloser, error) {
 	ret := _m.Called(ctx, imageName)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+	var r0 io.ReadCloser
+	if rf, ok := ret.Get(0).(func(context.Context, string) io.ReadCloser); ok {
 		r0 = rf(ctx, imageName)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, imageName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // RemoveContainer provides a mock function with given fields: ctx, containerID
@@ -104,13 +326,41 @@ func (_m *mockActions) RemoveContainer(ctx context.Context, containerID string)
 	return r0
 }
 
-// RemoveNetwork provides a mock function with given fields: ctx, containerName
-func (_m *mockActions) RemoveNetwork(ctx context.Context, containerName string) error {
-	ret := _m.Called(ctx, containerName)
+// RemoveNetwork provides a mock function with given fields: ctx, network
+func (_m *mockActions) RemoveNetwork(ctx context.Context, network docker.Network) error {
+	ret := _m.Called(ctx, network)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, containerName)
+	if rf, ok := ret.Get(0).(func(context.Context, docker.Network) error); ok {
+		r0 = rf(ctx, network)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResizeExec provides a mock function with given fields: ctx, execID, size
+func (_m *mockActions) ResizeExec(ctx context.Context, execID string, size docker.TerminalSize) error {
+	ret := _m.Called(ctx, execID, size)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, docker.TerminalSize) error); ok {
+		r0 = rf(ctx, execID, size)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RestartContainer provides a mock function with given fields: ctx, containerID, timeout
+func (_m *mockActions) RestartContainer(ctx context.Context, containerID string, timeout time.Duration) error {
+	ret := _m.Called(ctx, containerID, timeout)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) error); ok {
+		r0 = rf(ctx, containerID, timeout)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -132,20 +382,20 @@ func (_m *mockActions) StartContainer(ctx context.Context, containerID string) e
 	return r0
 }
 
-// StopContainer provides a mock function with given fields: ctx, imageName
-func (_m *mockActions) StopContainer(ctx context.Context, imageName string) (string, error) {
-	ret := _m.Called(ctx, imageName)
+// StopContainer provides a mock function with given fields: ctx, container
+func (_m *mockActions) StopContainer(ctx context.Context, container docker.Container) (string, error) {
+	ret := _m.Called(ctx, container)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = rf(ctx, imageName)
+	if rf, ok := ret.Get(0).(func(context.Context, docker.Container) string); ok {
+		r0 = rf(ctx, container)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, imageName)
+	if rf, ok := ret.Get(1).(func(context.Context, docker.Container) error); ok {
+		r1 = rf(ctx, container)
 	} else {
 		r1 = ret.Error(1)
 	}
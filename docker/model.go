@@ -1,8 +1,277 @@
 package docker
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Labels stamped on the containers to record which compose definition produced them.
+const (
+	LabelProject     = "com.docker.compose.project"
+	LabelService     = "com.docker.compose.service"
+	LabelConfigFiles = "com.docker.compose.project.config_files"
+	LabelConfigHash  = "com.docker.compose.config-hash"
+)
+
+// Conditions a container can be waited for.
+const (
+	ConditionStarted   = "service_started"
+	ConditionHealthy   = "service_healthy"
+	ConditionCompleted = "service_completed_successfully"
+)
+
+// Policies deciding when the image of a container is pulled or built, PullPolicyMissing is the default.
+const (
+	PullPolicyAlways  = "always"
+	PullPolicyMissing = "missing"
+	PullPolicyNever   = "never"
+	PullPolicyBuild   = "build"
+)
+
+// Mount types supported by containers.
+const (
+	MountTypeVolume = "volume"
+	MountTypeBind   = "bind"
+	MountTypeTmpfs  = "tmpfs"
+)
+
 // Container represents a docker container.
 type Container struct {
-	Name            string
-	Image           string
+	Name string
+	// Project, Service and ConfigFiles identify the compose definition the container was created from.
+	Project       string
+	Service       string
+	ConfigFiles   []string
+	Image         string
+	Build         *Build            `json:",omitempty"`
+	PullPolicy    string            `json:",omitempty"`
+	Command       []string          `json:",omitempty"`
+	Entrypoint    []string          `json:",omitempty"`
+	WorkingDir    string            `json:",omitempty"`
+	User          string            `json:",omitempty"`
+	Hostname      string            `json:",omitempty"`
+	Tty           bool              `json:",omitempty"`
+	StdinOpen     bool              `json:",omitempty"`
+	RestartPolicy *RestartPolicy    `json:",omitempty"`
+	Resources     *Resources        `json:",omitempty"`
+	Privileged    bool              `json:",omitempty"`
+	CapAdd        []string          `json:",omitempty"`
+	CapDrop       []string          `json:",omitempty"`
+	ReadOnly      bool              `json:",omitempty"`
+	SecurityOpt   []string          `json:",omitempty"`
+	Tmpfs         map[string]string `json:",omitempty"`
+	Sysctls       map[string]string `json:",omitempty"`
+	Ulimits       []Ulimit          `json:",omitempty"`
+	// Labels are the labels declared by the service, the compose labels are added to them.
+	Labels     map[string]string `json:",omitempty"`
+	ExtraHosts []string          `json:",omitempty"`
+	DNS        []string          `json:",omitempty"`
+	DNSSearch  []string          `json:",omitempty"`
+	Domainname string            `json:",omitempty"`
+	Logging    *Logging          `json:",omitempty"`
+	StopSignal string            `json:",omitempty"`
+	// StopGracePeriod is how long the container may take to stop before it is killed,
+	// the daemon default is used when it is zero.
+	StopGracePeriod time.Duration `json:",omitempty"`
 	EnvironmentVars []string
+	Ports           []Port
+	Mounts          []Mount
+	Volumes         []Volume
+	Networks        []Network
+	Healthcheck     *Healthcheck
+	// DependsOn maps the names of the containers this container depends on to the
+	// condition they have to satisfy before this container can be started.
+	DependsOn map[string]string
+}
+
+// ConfigHash returns a canonical hash of the container configuration. Containers
+// created from the same resolved service definition share the same hash. Optional
+// settings are left out of the hash when unset so that adding them does not change it.
+func (c Container) ConfigHash() string {
+	// The paths of the compose files and the pull policy do not change the container itself.
+	c.ConfigFiles = nil
+	c.PullPolicy = ""
+	c.EnvironmentVars = append([]string{}, c.EnvironmentVars...)
+	sort.Strings(c.EnvironmentVars)
+
+	// Marshaling cannot fail as the configuration only consists of plain values.
+	config, _ := json.Marshal(c)
+	hash := sha256.Sum256(config)
+
+	return hex.EncodeToString(hash[:])
+}
+
+// ComposeLabels returns the labels identifying the compose definition of the container.
+func (c Container) ComposeLabels() map[string]string {
+	return map[string]string{
+		LabelProject:     c.Project,
+		LabelService:     c.Service,
+		LabelConfigFiles: strings.Join(c.ConfigFiles, ","),
+		LabelConfigHash:  c.ConfigHash(),
+	}
+}
+
+// ProvisioningOptions represents how existing containers are treated when services are provisioned.
+type ProvisioningOptions struct {
+	// ForceRecreate recreates the containers even when their configuration did not change.
+	ForceRecreate bool
+	// NoRecreate keeps the existing containers even when their configuration changed.
+	NoRecreate bool
+	// PullPolicy overrides the pull policy of the containers when it is set. The build policy only
+	// applies to the containers whose image is built and the other policies to the others.
+	PullPolicy string
+}
+
+// PullResult represents the outcome of pulling an image used by one or more services.
+type PullResult struct {
+	Image    string
+	Services []string
+	// Skipped is the reason the image was not pulled, it is empty when it was.
+	Skipped string
+	Err     error
+}
+
+// Port represents a container port published on the host.
+type Port struct {
+	HostIP        string
+	HostPort      string
+	ContainerPort string
+	Protocol      string
+}
+
+// Mount represents a volume, bind or tmpfs mount of a container.
+type Mount struct {
+	Type        string
+	Source      string
+	Target      string
+	ReadOnly    bool
+	Propagation string
+	NoCopy      bool
+}
+
+// Volume represents a named volume used by a container.
+type Volume struct {
+	Name string
+	// Project is the name of the project the volume belongs to.
+	Project    string
+	Driver     string
+	DriverOpts map[string]string
+	Labels     map[string]string
+	External   bool
+}
+
+// Network represents a network a container is connected to.
+type Network struct {
+	Name string
+	// Project is the name of the project the network belongs to.
+	Project    string
+	Driver     string
+	DriverOpts map[string]string
+	Labels     map[string]string
+	External   bool
+	Internal   bool
+	Attachable bool
+	// Aliases are the names under which the container can be reached on the network.
+	Aliases []string
+}
+
+// Build represents how the image of a container is built from a Dockerfile. The Dockerfile
+// path is relative to the Context directory and defaults to "Dockerfile".
+type Build struct {
+	Context    string
+	Dockerfile string            `json:",omitempty"`
+	Args       map[string]string `json:",omitempty"`
+	Target     string            `json:",omitempty"`
+	Labels     map[string]string `json:",omitempty"`
+	CacheFrom  []string          `json:",omitempty"`
+}
+
+// RestartPolicy represents the policy docker restarts a container with when it exits.
+type RestartPolicy struct {
+	Name              string
+	MaximumRetryCount int
+}
+
+// Resources represents the resources a container is limited to. Memory sizes are in bytes
+// and NanoCPUs is the number of CPUs in units of 10^-9 CPUs.
+type Resources struct {
+	Memory            int64 `json:",omitempty"`
+	MemoryReservation int64 `json:",omitempty"`
+	NanoCPUs          int64 `json:",omitempty"`
+	CPUShares         int64 `json:",omitempty"`
+	PidsLimit         int64 `json:",omitempty"`
+	ShmSize           int64 `json:",omitempty"`
+}
+
+// Logging represents the logging driver of a container and its options.
+type Logging struct {
+	Driver  string
+	Options map[string]string
+}
+
+// Ulimit represents the soft and hard limits of a resource such as nofile.
+type Ulimit struct {
+	Name string
+	Soft int64
+	Hard int64
+}
+
+// Healthcheck represents the check run to determine whether a container is healthy.
+type Healthcheck struct {
+	Test        []string
+	Interval    time.Duration
+	Timeout     time.Duration
+	StartPeriod time.Duration
+	Retries     int
+}
+
+// ServiceStatus represents the state of the container running a service.
+type ServiceStatus struct {
+	Service     string   `json:"service"`
+	ContainerID string   `json:"containerId"`
+	Image       string   `json:"image"`
+	State       string   `json:"state"`
+	Health      string   `json:"health,omitempty"`
+	Uptime      string   `json:"uptime,omitempty"`
+	Ports       []string `json:"ports,omitempty"`
+	Networks    []string `json:"networks,omitempty"`
+}
+
+// LogOptions represents which part of the container logs is read and how.
+type LogOptions struct {
+	Follow     bool
+	Tail       string
+	Since      string
+	Timestamps bool
+}
+
+// ExecOptions represents a command run inside a running container.
+type ExecOptions struct {
+	Command    []string
+	User       string
+	WorkingDir string
+	Env        []string
+	// Tty allocates a pseudo terminal, Interactive keeps stdin attached.
+	Tty         bool
+	Interactive bool
+}
+
+// ExecStreams represents the standard streams connected to an exec session. The
+// sizes sent on Resize are applied to the terminal of the session.
+type ExecStreams struct {
+	In     io.Reader
+	Out    io.Writer
+	Err    io.Writer
+	Resize <-chan TerminalSize
+}
+
+// TerminalSize represents the dimensions of a terminal.
+type TerminalSize struct {
+	Height uint
+	Width  uint
 }
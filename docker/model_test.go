@@ -0,0 +1,55 @@
+package docker_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/petrovskiborislav/docker-cli/docker"
+)
+
+func TestConfigHash_WhenEnvironmentOrderDiffers_ThenHashIsEqual(t *testing.T) {
+	// Arrange
+	container1 := docker.Container{Name: "db", Image: "mysql", EnvironmentVars: []string{"A=1", "B=2"}, ConfigFiles: []string{"/a.yaml"}}
+	container2 := docker.Container{Name: "db", Image: "mysql", EnvironmentVars: []string{"B=2", "A=1"}, ConfigFiles: []string{"/b.yaml"}}
+
+	// Act
+	hash1 := container1.ConfigHash()
+	hash2 := container2.ConfigHash()
+
+	// Assert
+	assert.Equal(t, hash1, hash2)
+	assert.Equal(t, []string{"A=1", "B=2"}, container1.EnvironmentVars)
+	assert.Equal(t, []string{"B=2", "A=1"}, container2.EnvironmentVars)
+}
+
+func TestConfigHash_WhenConfigurationDiffers_ThenHashDiffers(t *testing.T) {
+	// Arrange
+	container1 := docker.Container{Name: "db", Image: "mysql:5"}
+	container2 := docker.Container{Name: "db", Image: "mysql:8"}
+
+	// Act
+	hash1 := container1.ConfigHash()
+	hash2 := container2.ConfigHash()
+
+	// Assert
+	assert.NotEqual(t, hash1, hash2)
+}
+
+func TestLabels_ThenSuccess(t *testing.T) {
+	// Arrange
+	container := docker.Container{Name: "db", Project: "project", Service: "db", ConfigFiles: []string{"/a.yaml", "/b.yaml"}}
+
+	// Act
+	result := container.ComposeLabels()
+
+	// Assert
+	want := map[string]string{
+		docker.LabelProject:     "project",
+		docker.LabelService:     "db",
+		docker.LabelConfigFiles: "/a.yaml,/b.yaml",
+		docker.LabelConfigHash:  container.ConfigHash(),
+	}
+
+	assert.Equal(t, want, result)
+}
@@ -3,12 +3,14 @@ package logger
 import (
 	"io"
 	"log"
+	"sync"
 
 	"github.com/fatih/color"
 )
 
 type Logger struct {
 	logger     *log.Logger
+	mu         *sync.Mutex
 	paramColor func(a ...interface{}) string
 	infoColor  func(w io.Writer, format string, a ...interface{})
 	warnColor  func(w io.Writer, format string, a ...interface{})
@@ -19,6 +21,7 @@ type Logger struct {
 func NewLogger() Logger {
 	return Logger{
 		logger:     log.Default(),
+		mu:         &sync.Mutex{},
 		paramColor: color.New(color.FgBlue).SprintFunc(),
 		infoColor:  color.New(color.FgGreen).FprintfFunc(),
 		warnColor:  color.New(color.FgYellow).FprintfFunc(),
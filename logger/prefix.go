@@ -0,0 +1,78 @@
+package logger
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/fatih/color"
+)
+
+var prefixColors = []color.Attribute{
+	color.FgCyan,
+	color.FgYellow,
+	color.FgGreen,
+	color.FgMagenta,
+	color.FgBlue,
+	color.FgHiCyan,
+	color.FgHiYellow,
+	color.FgHiGreen,
+	color.FgHiMagenta,
+	color.FgHiBlue,
+}
+
+// PrefixWriter writes every line it receives prefixed with a colored name. Writers
+// created by the same logger never interleave their lines.
+type PrefixWriter struct {
+	mu     *sync.Mutex
+	out    io.Writer
+	prefix string
+	buf    []byte
+}
+
+// PrefixWriter creates a writer which prefixes the lines written to out with name. The
+// color of the name is picked by index so concurrent streams can be told apart.
+func (l Logger) PrefixWriter(out io.Writer, name string, index int) *PrefixWriter {
+	prefixColor := color.New(prefixColors[index%len(prefixColors)]).SprintFunc()
+
+	return &PrefixWriter{mu: l.mu, out: out, prefix: prefixColor(name + " | ")}
+}
+
+// Write writes the complete lines of p and buffers the rest until its line ends.
+func (w *PrefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+
+		if err := w.writeLine(w.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+1:]
+	}
+
+	return len(p), nil
+}
+
+// Flush writes the buffered line which was not terminated by a new line.
+func (w *PrefixWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	line := append(w.buf, '\n')
+	w.buf = nil
+
+	return w.writeLine(line)
+}
+
+func (w *PrefixWriter) writeLine(line []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, err := io.WriteString(w.out, w.prefix+string(line))
+	return err
+}
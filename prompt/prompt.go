@@ -1,9 +1,17 @@
 package prompt
 
 import (
+	"errors"
+	"os"
+
+	"github.com/mattn/go-isatty"
 	"gopkg.in/AlecAivazis/survey.v1"
+	"gopkg.in/AlecAivazis/survey.v1/terminal"
 )
 
+// ErrNotTerminal is returned when the prompt cannot be shown because the input is not a terminal.
+var ErrNotTerminal = errors.New("prompt requires an interactive terminal")
+
 // Prompt is an interface for a prompt.
 type Prompt interface {
 	SelectPrompt(label string, items []string, opts ...survey.AskOpt) ([]string, error)
@@ -17,7 +25,20 @@ func NewPrompt() Prompt {
 type prompt struct{}
 
 // SelectPrompt creates a prompt which allows the user to select multiple options.
+// It fails with ErrNotTerminal instead of waiting for input which never comes.
 func (p prompt) SelectPrompt(label string, items []string, opts ...survey.AskOpt) ([]string, error) {
+	options := survey.AskOptions{Stdio: terminal.Stdio{In: os.Stdin, Out: os.Stdout, Err: os.Stderr}}
+	for _, opt := range opts {
+		if err := opt(&options); err != nil {
+			return nil, err
+		}
+	}
+
+	fd := options.Stdio.In.Fd()
+	if !isatty.IsTerminal(fd) && !isatty.IsCygwinTerminal(fd) {
+		return nil, ErrNotTerminal
+	}
+
 	var result []string
 	prompt := &survey.MultiSelect{
 		Message:  label,
@@ -69,6 +69,26 @@ func TestSelectPrompt_WhenErrorOccursOnCreationOfPrompt(t *testing.T) {
 	assert.Empty(t, result)
 }
 
+func TestSelectPrompt_WhenInputIsNotTerminal(t *testing.T) {
+	// Arrange
+	msg := "Select items"
+	items := []string{"item1", "item2", "item3"}
+
+	in, out, err := os.Pipe()
+	assert.NoError(t, err)
+	defer in.Close()
+	defer out.Close()
+
+	opt := survey.WithStdio(in, out, out)
+
+	// Act
+	result, err := prompt.NewPrompt().SelectPrompt(msg, items, opt)
+
+	// Assert
+	assert.ErrorIs(t, err, prompt.ErrNotTerminal)
+	assert.Empty(t, result)
+}
+
 // Helpers
 type expectConsole interface {
 	Send(string)
@@ -0,0 +1,91 @@
+package yaml
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultBuildContext is the build context used when a build does not declare one.
+const DefaultBuildContext = "."
+
+// Build is a struct which represents how the image of a service is built. It can be declared
+// either as the path of the build context or as a mapping of the build options. Like environment
+// variables, build arguments declared without a value take their value from the host environment.
+type Build struct {
+	Context    string             `yaml:"context,omitempty"`
+	Dockerfile string             `yaml:"dockerfile,omitempty"`
+	Args       ServiceEnvironment `yaml:"args,omitempty"`
+	Target     string             `yaml:"target,omitempty"`
+	Labels     Mapping            `yaml:"labels,omitempty"`
+	CacheFrom  []string           `yaml:"cache_from,omitempty"`
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (b *Build) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		*b = Build{Context: value.Value}
+	case yaml.MappingNode:
+		type plain Build
+		var build plain
+		if err := value.Decode(&build); err != nil {
+			return err
+		}
+		*b = Build(build)
+	default:
+		return fmt.Errorf("line %d: build must be a string or a mapping", value.Line)
+	}
+
+	if b.Context == "" {
+		b.Context = DefaultBuildContext
+	}
+
+	return nil
+}
+
+// resolveBuildContext makes the build context absolute, a relative
+// context being resolved against the directory of the compose file.
+func resolveBuildContext(build *Build, workingDir string) {
+	if build == nil {
+		return
+	}
+
+	if !filepath.IsAbs(build.Context) {
+		build.Context = filepath.Join(workingDir, build.Context)
+	}
+	build.Context = filepath.Clean(build.Context)
+}
+
+// Pull policies a service can be declared with.
+const (
+	PullPolicyAlways  = "always"
+	PullPolicyMissing = "missing"
+	PullPolicyNever   = "never"
+	PullPolicyBuild   = "build"
+	// PullPolicyIfNotPresent is an alias of PullPolicyMissing.
+	PullPolicyIfNotPresent = "if_not_present"
+)
+
+// PullPolicy decides when the image of a service is pulled or built. "if_not_present"
+// is accepted as an alias of "missing", which is the default.
+type PullPolicy string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (p *PullPolicy) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.ScalarNode {
+		return fmt.Errorf("line %d: pull_policy must be a string", value.Line)
+	}
+
+	switch value.Value {
+	case PullPolicyAlways, PullPolicyMissing, PullPolicyNever, PullPolicyBuild:
+		*p = PullPolicy(value.Value)
+	case PullPolicyIfNotPresent:
+		*p = PullPolicyMissing
+	default:
+		return fmt.Errorf("line %d: unknown pull policy %q", value.Line, value.Value)
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package yaml
+
+import (
+	"fmt"
+
+	"github.com/kballard/go-shellquote"
+	"gopkg.in/yaml.v3"
+)
+
+// ShellCommand is a command with its arguments. It can be declared either as a list or as a
+// string which is split into words following the shell quoting rules. An empty string
+// declares an empty command, which e.g. resets the entrypoint of the image.
+type ShellCommand []string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (c *ShellCommand) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		words, err := shellquote.Split(value.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: invalid command %q: %s", value.Line, value.Value, err)
+		}
+		*c = append(ShellCommand{}, words...)
+	case yaml.SequenceNode:
+		var words []string
+		if err := value.Decode(&words); err != nil {
+			return err
+		}
+		*c = append(ShellCommand{}, words...)
+	default:
+		return fmt.Errorf("line %d: command must be a string or a list", value.Line)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package yaml
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Conditions a dependency has to satisfy before the dependent service is started.
+const (
+	ConditionServiceStarted               = "service_started"
+	ConditionServiceHealthy               = "service_healthy"
+	ConditionServiceCompletedSuccessfully = "service_completed_successfully"
+)
+
+// ServiceDependency is a struct which represents a dependency of a service.
+type ServiceDependency struct {
+	Condition string `yaml:"condition"`
+}
+
+// ServiceDependencies maps the names of the services a service depends on to their
+// dependency configuration. It can be declared either as a list or as a map.
+type ServiceDependencies map[string]ServiceDependency
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (d *ServiceDependencies) UnmarshalYAML(value *yaml.Node) error {
+	dependencies := ServiceDependencies{}
+	switch value.Kind {
+	case yaml.SequenceNode:
+		var names []string
+		if err := value.Decode(&names); err != nil {
+			return err
+		}
+		for _, name := range names {
+			dependencies[name] = ServiceDependency{Condition: ConditionServiceStarted}
+		}
+	case yaml.MappingNode:
+		var definitions map[string]ServiceDependency
+		if err := value.Decode(&definitions); err != nil {
+			return err
+		}
+		for name, dependency := range definitions {
+			switch dependency.Condition {
+			case "":
+				dependency.Condition = ConditionServiceStarted
+			case ConditionServiceStarted, ConditionServiceHealthy, ConditionServiceCompletedSuccessfully:
+			default:
+				return fmt.Errorf("line %d: unknown condition %q for dependency %s", value.Line, dependency.Condition, name)
+			}
+			dependencies[name] = dependency
+		}
+	default:
+		return fmt.Errorf("line %d: depends_on must be a list or a map", value.Line)
+	}
+
+	*d = dependencies
+	return nil
+}
+
+// ResolveDependencies returns the given services together with all the services they
+// depend on, ordered so that every service comes after its dependencies.
+func (c *ComposeFile) ResolveDependencies(names []string) ([]string, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	var ordered []string
+	state := make(map[string]int, len(c.Services))
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		service, ok := c.Services[name]
+		if !ok {
+			return fmt.Errorf("service %s is not defined", name)
+		}
+
+		path = append(path, name)
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("dependency cycle detected: %s", strings.Join(path, " -> "))
+		case visited:
+			return nil
+		}
+
+		state[name] = visiting
+		for _, dependency := range sortedKeys(service.DependsOn) {
+			if err := visit(dependency, path); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		ordered = append(ordered, name)
+
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+func sortedKeys(dependencies ServiceDependencies) []string {
+	keys := make([]string, 0, len(dependencies))
+	for key := range dependencies {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,145 @@
+package yaml
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ServiceEnvironment maps the environment variables of a service to their values. It can be declared
+// either as a map or as a list of KEY=value entries. Variables declared without a value
+// (a bare KEY in the list or a null in the map) take their value from the host environment
+// and are left out when the host does not define them.
+type ServiceEnvironment map[string]string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (e *ServiceEnvironment) UnmarshalYAML(value *yaml.Node) error {
+	environment := ServiceEnvironment{}
+	switch value.Kind {
+	case yaml.SequenceNode:
+		for _, item := range value.Content {
+			if item.Kind != yaml.ScalarNode {
+				return fmt.Errorf("line %d: environment entries must be strings", item.Line)
+			}
+
+			key, val, ok := strings.Cut(item.Value, "=")
+			if key == "" {
+				return fmt.Errorf("line %d: invalid environment entry %q", item.Line, item.Value)
+			}
+			if ok {
+				environment[key] = val
+			} else {
+				environment.inherit(key)
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(value.Content); i += 2 {
+			key, val := value.Content[i], value.Content[i+1]
+			if val.Kind != yaml.ScalarNode {
+				return fmt.Errorf("line %d: environment variable %s must be a string, number, boolean or null", val.Line, key.Value)
+			}
+
+			// Booleans and numbers keep the text they were written with, e.g. "true" or "1.10".
+			if val.ShortTag() == "!!null" {
+				environment.inherit(key.Value)
+			} else {
+				environment[key.Value] = val.Value
+			}
+		}
+	default:
+		return fmt.Errorf("line %d: environment must be a list or a map", value.Line)
+	}
+
+	*e = environment
+	return nil
+}
+
+func (e ServiceEnvironment) inherit(key string) {
+	if value, ok := os.LookupEnv(key); ok {
+		e[key] = value
+	}
+}
+
+// EnvFile is a struct which represents a file the environment variables of a service are read from.
+type EnvFile struct {
+	Path     string `yaml:"path"`
+	Required bool   `yaml:"required"`
+}
+
+// ServiceEnvFiles is a list of env files which can be declared as a single path,
+// a list of paths or a list of path and required pairs.
+type ServiceEnvFiles []EnvFile
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (f *ServiceEnvFiles) UnmarshalYAML(value *yaml.Node) error {
+	var items []*yaml.Node
+	switch value.Kind {
+	case yaml.ScalarNode:
+		items = []*yaml.Node{value}
+	case yaml.SequenceNode:
+		items = value.Content
+	default:
+		return fmt.Errorf("line %d: env_file must be a string or a list", value.Line)
+	}
+
+	var files ServiceEnvFiles
+	for _, item := range items {
+		file := EnvFile{Required: true}
+		switch item.Kind {
+		case yaml.ScalarNode:
+			file.Path = item.Value
+		case yaml.MappingNode:
+			if err := item.Decode(&file); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("line %d: invalid env_file definition", item.Line)
+		}
+
+		if file.Path == "" {
+			return fmt.Errorf("line %d: env_file path is required", item.Line)
+		}
+		files = append(files, file)
+	}
+
+	*f = files
+	return nil
+}
+
+// resolveEnvFiles makes the env file paths absolute and merges their variables into the service
+// environment. Later files override earlier ones and the environment attribute overrides them all.
+func resolveEnvFiles(service *Service, dir string) error {
+	if len(service.EnvFiles) == 0 {
+		return nil
+	}
+
+	environment := ServiceEnvironment{}
+	for i, file := range service.EnvFiles {
+		if !filepath.IsAbs(file.Path) {
+			service.EnvFiles[i].Path = filepath.Join(dir, file.Path)
+		}
+
+		variables, err := readEnvFile(service.EnvFiles[i].Path)
+		if errors.Is(err, os.ErrNotExist) && !file.Required {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("error reading env file: %s", err)
+		}
+
+		for key, value := range variables {
+			environment[key] = value
+		}
+	}
+
+	for key, value := range service.EnvironmentVars {
+		environment[key] = value
+	}
+	service.EnvironmentVars = environment
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package yaml
+
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// HealthCheck is a struct which represents the healthcheck of a service.
+type HealthCheck struct {
+	Test        HealthCheckTest `yaml:"test,omitempty"`
+	Interval    time.Duration   `yaml:"interval,omitempty"`
+	Timeout     time.Duration   `yaml:"timeout,omitempty"`
+	Retries     int             `yaml:"retries,omitempty"`
+	StartPeriod time.Duration   `yaml:"start_period,omitempty"`
+	Disable     bool            `yaml:"disable,omitempty"`
+}
+
+// HealthCheckTest is the command run to check the health of a service. The string
+// form is run with the container's default shell as it would be by "CMD-SHELL".
+type HealthCheckTest []string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (t *HealthCheckTest) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		*t = HealthCheckTest{"CMD-SHELL", value.Value}
+		return nil
+	case yaml.SequenceNode:
+		var test []string
+		if err := value.Decode(&test); err != nil {
+			return err
+		}
+
+		if len(test) == 0 {
+			return fmt.Errorf("line %d: healthcheck test must not be empty", value.Line)
+		}
+
+		switch test[0] {
+		case "NONE", "CMD", "CMD-SHELL":
+		default:
+			return fmt.Errorf("line %d: healthcheck test must start with NONE, CMD or CMD-SHELL", value.Line)
+		}
+
+		*t = test
+		return nil
+	default:
+		return fmt.Errorf("line %d: healthcheck test must be a string or a list", value.Line)
+	}
+}
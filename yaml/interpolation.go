@@ -0,0 +1,257 @@
+package yaml
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvFileName is the name of the file next to the compose file from which interpolation variables are read.
+const EnvFileName = ".env"
+
+// lookupFunc returns the value of a variable and whether it is set.
+type lookupFunc func(name string) (string, bool)
+
+// environmentLookup returns a lookup which prefers the process environment
+// over the variables declared in the .env file of the given directory.
+func environmentLookup(dir string) (lookupFunc, error) {
+	dotEnv, err := readEnvFile(filepath.Join(dir, EnvFileName))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	return func(name string) (string, bool) {
+		if value, ok := os.LookupEnv(name); ok {
+			return value, true
+		}
+		value, ok := dotEnv[name]
+		return value, ok
+	}, nil
+}
+
+// readEnvFile reads KEY=VALUE pairs from a file, ignoring blank lines and comments.
+func readEnvFile(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	variables := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("%s line %d: invalid variable declaration %q", path, lineNumber, line)
+		}
+
+		variables[key] = unquoteEnvValue(strings.TrimSpace(value))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return variables, nil
+}
+
+func unquoteEnvValue(value string) string {
+	if len(value) < 2 {
+		return value
+	}
+
+	switch value[0] {
+	case '"':
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			return unquoted
+		}
+	case '\'':
+		if value[len(value)-1] == '\'' {
+			return value[1 : len(value)-1]
+		}
+	}
+
+	return value
+}
+
+// interpolateNode substitutes variables in every scalar value of the node tree.
+// Mapping keys are left untouched and are used to build the path reported in errors.
+func interpolateNode(node *yaml.Node, path string, lookup lookupFunc) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			if err := interpolateNode(child, path, lookup); err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			if path != "" {
+				key = path + "." + key
+			}
+			if err := interpolateNode(node.Content[i+1], key, lookup); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			if err := interpolateNode(child, fmt.Sprintf("%s[%d]", path, i), lookup); err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		if !strings.Contains(node.Value, "$") {
+			return nil
+		}
+
+		value, err := interpolate(node.Value, lookup)
+		if err != nil {
+			return fmt.Errorf("line %d: error interpolating %s: %s", node.Line, path, err)
+		}
+
+		node.Value = value
+		// Unquoted values are resolved again so that "${PORT}" can still be decoded as a number,
+		// but a substituted value never turns into null.
+		if node.Style&(yaml.SingleQuotedStyle|yaml.DoubleQuotedStyle|yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+			node.Tag = ""
+			if node.ShortTag() == "!!null" {
+				node.Tag = "!!str"
+			}
+		}
+	}
+
+	return nil
+}
+
+// interpolate substitutes $VAR, ${VAR}, ${VAR:-default}, ${VAR-default},
+// ${VAR:?error} and ${VAR?error} in the value, and unescapes $$ into $.
+func interpolate(value string, lookup lookupFunc) (string, error) {
+	var result strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '$' {
+			result.WriteByte(value[i])
+			continue
+		}
+
+		if i+1 == len(value) {
+			return "", fmt.Errorf("invalid interpolation format %q: escape $ with $$", value)
+		}
+
+		switch next := value[i+1]; {
+		case next == '$':
+			result.WriteByte('$')
+			i++
+		case next == '{':
+			end := closingBrace(value, i+2)
+			if end < 0 {
+				return "", fmt.Errorf("invalid interpolation format %q: missing closing brace", value)
+			}
+			substituted, err := substitute(value[i+2:end], lookup)
+			if err != nil {
+				return "", err
+			}
+			result.WriteString(substituted)
+			i = end
+		case isNameStart(next):
+			end := i + 1
+			for end < len(value) && isNameChar(value[end]) {
+				end++
+			}
+			substituted, _ := lookup(value[i+1 : end])
+			result.WriteString(substituted)
+			i = end - 1
+		default:
+			return "", fmt.Errorf("invalid interpolation format %q: escape $ with $$", value)
+		}
+	}
+
+	return result.String(), nil
+}
+
+// substitute resolves the content of a braced expression such as "VAR:-default".
+func substitute(expression string, lookup lookupFunc) (string, error) {
+	end := 0
+	for end < len(expression) && isNameChar(expression[end]) {
+		end++
+	}
+
+	name, modifier := expression[:end], expression[end:]
+	if name == "" || !isNameStart(name[0]) {
+		return "", fmt.Errorf("invalid variable name in ${%s}", expression)
+	}
+
+	value, ok := lookup(name)
+	switch {
+	case modifier == "":
+		return value, nil
+	case strings.HasPrefix(modifier, ":-"):
+		if !ok || value == "" {
+			return interpolate(modifier[2:], lookup)
+		}
+	case strings.HasPrefix(modifier, "-"):
+		if !ok {
+			return interpolate(modifier[1:], lookup)
+		}
+	case strings.HasPrefix(modifier, ":?"):
+		if !ok || value == "" {
+			return "", requiredVariableError(name, modifier[2:], lookup)
+		}
+	case strings.HasPrefix(modifier, "?"):
+		if !ok {
+			return "", requiredVariableError(name, modifier[1:], lookup)
+		}
+	default:
+		return "", fmt.Errorf("invalid interpolation format ${%s}", expression)
+	}
+
+	return value, nil
+}
+
+func requiredVariableError(name, message string, lookup lookupFunc) error {
+	message, err := interpolate(message, lookup)
+	if err != nil {
+		return err
+	}
+	if message == "" {
+		return fmt.Errorf("required variable %s is missing a value", name)
+	}
+	return fmt.Errorf("required variable %s is missing a value: %s", name, message)
+}
+
+// closingBrace returns the index of the brace closing the expression starting at start, honouring nested braces.
+func closingBrace(value string, start int) int {
+	depth := 1
+	for i := start; i < len(value); i++ {
+		switch value[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,223 @@
+package yaml
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// replacedSequences are the paths of the lists which are replaced instead of appended when files
+// are merged. The name of a service is written as "*" in the paths.
+var replacedSequences = map[string]bool{
+	"services.*.command":          true,
+	"services.*.entrypoint":       true,
+	"services.*.healthcheck.test": true,
+}
+
+// mappedSequences are the paths of the lists which can be declared either as a list or as a map.
+// Their lists are converted to maps before merging so both forms can override each other.
+var mappedSequences = map[string]func(item *yaml.Node) (key *yaml.Node, value *yaml.Node){
+	"services.*.environment": splitKeyValue,
+	"services.*.labels":      splitKeyValue,
+	"services.*.build.args":  splitKeyValue,
+	"services.*.sysctls":     splitKeyValue,
+	"services.*.depends_on":  emptyMapping,
+	"services.*.networks":    emptyMapping,
+}
+
+// keyedSequences are the paths of the lists whose entries are identified by a key, in both their
+// short and long form. An override entry replaces the base entries with the same key.
+var keyedSequences = map[string]func(item *yaml.Node) (key string, ok bool){
+	"services.*.volumes": volumeMergeKey,
+	"services.*.ports":   portMergeKey,
+}
+
+// mergeNodes merges the override node into the base node following the compose specification:
+// maps are merged key by key, lists are appended without duplicates and scalars are overridden.
+// The path holds the keys leading to the nodes.
+func mergeNodes(base, override *yaml.Node, path []string) *yaml.Node {
+	pattern := pathPattern(path)
+	if toMapping, ok := mappedSequences[pattern]; ok {
+		base = sequenceToMapping(base, toMapping)
+		override = sequenceToMapping(override, toMapping)
+	}
+
+	if base.Kind != override.Kind {
+		return override
+	}
+
+	switch base.Kind {
+	case yaml.DocumentNode:
+		if len(base.Content) == 0 || len(override.Content) == 0 {
+			return override
+		}
+		base.Content[0] = mergeNodes(base.Content[0], override.Content[0], path)
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(override.Content); i += 2 {
+			overrideKey, overrideValue := override.Content[i], override.Content[i+1]
+
+			index := mappingIndex(base, overrideKey.Value)
+			if index < 0 {
+				base.Content = append(base.Content, overrideKey, overrideValue)
+				continue
+			}
+			// The path is copied so sibling keys never share its backing array.
+			childPath := append(path[:len(path):len(path)], overrideKey.Value)
+			base.Content[index+1] = mergeNodes(base.Content[index+1], overrideValue, childPath)
+		}
+	case yaml.SequenceNode:
+		if replacedSequences[pattern] {
+			return override
+		}
+		if mergeKey, ok := keyedSequences[pattern]; ok {
+			base.Content = mergeKeyedItems(base.Content, override.Content, mergeKey)
+			break
+		}
+		for _, item := range override.Content {
+			if !containsScalar(base, item) {
+				base.Content = append(base.Content, item)
+			}
+		}
+	default:
+		return override
+	}
+
+	return base
+}
+
+// pathPattern joins the keys of the path with dots, replacing the name of a service with "*".
+func pathPattern(path []string) string {
+	if len(path) > 1 && path[0] == "services" {
+		path = append([]string{path[0], "*"}, path[2:]...)
+	}
+	return strings.Join(path, ".")
+}
+
+// mergeKeyedItems appends the override items to the base items, dropping the base items which
+// have the same key as an override item. Items without a key are appended unless already present.
+func mergeKeyedItems(base, override []*yaml.Node, mergeKey func(item *yaml.Node) (string, bool)) []*yaml.Node {
+	overridden := map[string]bool{}
+	for _, item := range override {
+		if key, ok := mergeKey(item); ok {
+			overridden[key] = true
+		}
+	}
+
+	merged := make([]*yaml.Node, 0, len(base)+len(override))
+	for _, item := range base {
+		if key, ok := mergeKey(item); ok && overridden[key] {
+			continue
+		}
+		merged = append(merged, item)
+	}
+
+	sequence := &yaml.Node{Kind: yaml.SequenceNode, Content: merged}
+	for _, item := range override {
+		if !containsScalar(sequence, item) {
+			sequence.Content = append(sequence.Content, item)
+		}
+	}
+
+	return sequence.Content
+}
+
+// volumeMergeKey identifies a volume entry by the path it is mounted at inside the container.
+func volumeMergeKey(item *yaml.Node) (string, bool) {
+	switch item.Kind {
+	case yaml.ScalarNode:
+		volume, err := parseShortVolume(item.Value)
+		if err != nil {
+			return "", false
+		}
+		return volume.Target, true
+	case yaml.MappingNode:
+		if index := mappingIndex(item, "target"); index >= 0 {
+			return item.Content[index+1].Value, true
+		}
+	}
+	return "", false
+}
+
+// portMergeKey identifies a port entry by its container port and protocol. Short entries
+// declaring a range of ports have no key.
+func portMergeKey(item *yaml.Node) (string, bool) {
+	switch item.Kind {
+	case yaml.ScalarNode:
+		ports, err := parseShortPort(item.Value)
+		if err != nil || len(ports) != 1 {
+			return "", false
+		}
+		return fmt.Sprintf("%d/%s", ports[0].Target, ports[0].Protocol), true
+	case yaml.MappingNode:
+		index := mappingIndex(item, "target")
+		if index < 0 {
+			return "", false
+		}
+		protocol := "tcp"
+		if protocolIndex := mappingIndex(item, "protocol"); protocolIndex >= 0 {
+			protocol = item.Content[protocolIndex+1].Value
+		}
+		return item.Content[index+1].Value + "/" + protocol, true
+	}
+	return "", false
+}
+
+func mappingIndex(node *yaml.Node, key string) int {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// containsScalar reports whether the sequence already holds a scalar with the same value as the item.
+func containsScalar(sequence, item *yaml.Node) bool {
+	if item.Kind != yaml.ScalarNode {
+		return false
+	}
+	for _, existing := range sequence.Content {
+		if existing.Kind == yaml.ScalarNode && existing.Value == item.Value {
+			return true
+		}
+	}
+	return false
+}
+
+func sequenceToMapping(node *yaml.Node, toMapping func(item *yaml.Node) (*yaml.Node, *yaml.Node)) *yaml.Node {
+	if node.Kind != yaml.SequenceNode {
+		return node
+	}
+
+	mapping := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: node.Line, Column: node.Column}
+	for _, item := range node.Content {
+		if item.Kind != yaml.ScalarNode {
+			return node
+		}
+		key, value := toMapping(item)
+		if index := mappingIndex(mapping, key.Value); index >= 0 {
+			mapping.Content[index+1] = value
+			continue
+		}
+		mapping.Content = append(mapping.Content, key, value)
+	}
+
+	return mapping
+}
+
+// splitKeyValue converts a KEY=value entry into a key and a string value, a bare KEY gets a null value.
+func splitKeyValue(item *yaml.Node) (*yaml.Node, *yaml.Node) {
+	key, value, ok := strings.Cut(item.Value, "=")
+	valueNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Line: item.Line, Column: item.Column}
+	if ok {
+		valueNode.Tag, valueNode.Value = "!!str", value
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key, Line: item.Line, Column: item.Column}, valueNode
+}
+
+// emptyMapping converts a name entry into a key with an empty definition.
+func emptyMapping(item *yaml.Node) (*yaml.Node, *yaml.Node) {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: item.Value, Line: item.Line, Column: item.Column},
+		&yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: item.Line, Column: item.Column}
+}
@@ -0,0 +1,77 @@
+package yaml
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultNetwork is the network services join when they do not declare any networks.
+const DefaultNetwork = "default"
+
+// Network is a struct which represents a network declared
+// in the top-level networks section of a composer YAML file.
+type Network struct {
+	Name       string            `yaml:"name,omitempty"`
+	Driver     string            `yaml:"driver,omitempty"`
+	DriverOpts map[string]string `yaml:"driver_opts,omitempty"`
+	External   bool              `yaml:"external,omitempty"`
+	Internal   bool              `yaml:"internal,omitempty"`
+	Attachable bool              `yaml:"attachable,omitempty"`
+	Labels     Mapping           `yaml:"labels,omitempty"`
+}
+
+// ServiceNetwork is a struct which represents the attachment of a service to a network.
+type ServiceNetwork struct {
+	Aliases []string `yaml:"aliases,omitempty"`
+}
+
+// ServiceNetworks maps the names of the networks a service joins to their
+// attachment configuration. It can be declared either as a list or as a map.
+type ServiceNetworks map[string]ServiceNetwork
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (n *ServiceNetworks) UnmarshalYAML(value *yaml.Node) error {
+	networks := ServiceNetworks{}
+	switch value.Kind {
+	case yaml.SequenceNode:
+		var names []string
+		if err := value.Decode(&names); err != nil {
+			return err
+		}
+		for _, name := range names {
+			networks[name] = ServiceNetwork{}
+		}
+	case yaml.MappingNode:
+		var definitions map[string]*ServiceNetwork
+		if err := value.Decode(&definitions); err != nil {
+			return err
+		}
+		for name, definition := range definitions {
+			if definition == nil {
+				definition = &ServiceNetwork{}
+			}
+			networks[name] = *definition
+		}
+	default:
+		return fmt.Errorf("line %d: networks must be a list or a map", value.Line)
+	}
+
+	*n = networks
+	return nil
+}
+
+// NetworkName returns the name of the docker network created for the network declared under key.
+// Networks are prefixed with the project name unless they are named explicitly or external.
+func (c *ComposeFile) NetworkName(key string) string {
+	network := c.Networks[key]
+	if network.Name != "" {
+		return network.Name
+	}
+
+	if network.External {
+		return key
+	}
+
+	return fmt.Sprintf("%s_%s", c.Name, key)
+}
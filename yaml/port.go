@@ -0,0 +1,79 @@
+package yaml
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/docker/go-connections/nat"
+	"gopkg.in/yaml.v3"
+)
+
+// ServicePort is a struct which represents a port published by a service.
+type ServicePort struct {
+	Target    uint32 `yaml:"target"`
+	Published string `yaml:"published,omitempty"`
+	HostIP    string `yaml:"host_ip,omitempty"`
+	Protocol  string `yaml:"protocol,omitempty"`
+	Mode      string `yaml:"mode,omitempty"`
+}
+
+// ServicePorts is a list of service ports which can be declared
+// with the short ("127.0.0.1:8080:80/udp") or the long syntax.
+type ServicePorts []ServicePort
+
+// UnmarshalYAML implements yaml.Unmarshaler and expands port ranges into single ports.
+func (p *ServicePorts) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.SequenceNode {
+		return fmt.Errorf("line %d: ports must be a list", value.Line)
+	}
+
+	var ports ServicePorts
+	for _, item := range value.Content {
+		switch item.Kind {
+		case yaml.ScalarNode:
+			parsed, err := parseShortPort(item.Value)
+			if err != nil {
+				return fmt.Errorf("line %d: %s", item.Line, err)
+			}
+			ports = append(ports, parsed...)
+		case yaml.MappingNode:
+			var port ServicePort
+			if err := item.Decode(&port); err != nil {
+				return err
+			}
+			if port.Target == 0 {
+				return fmt.Errorf("line %d: port target is required", item.Line)
+			}
+			ports = append(ports, port)
+		default:
+			return fmt.Errorf("line %d: invalid port definition", item.Line)
+		}
+	}
+
+	*p = ports
+	return nil
+}
+
+func parseShortPort(spec string) ([]ServicePort, error) {
+	mappings, err := nat.ParsePortSpec(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %q: %s", spec, err)
+	}
+
+	var ports []ServicePort
+	for _, mapping := range mappings {
+		target, err := strconv.ParseUint(mapping.Port.Port(), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q: %s", spec, err)
+		}
+
+		ports = append(ports, ServicePort{
+			Target:    uint32(target),
+			Published: mapping.Binding.HostPort,
+			HostIP:    mapping.Binding.HostIP,
+			Protocol:  mapping.Port.Proto(),
+		})
+	}
+
+	return ports, nil
+}
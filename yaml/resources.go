@@ -0,0 +1,118 @@
+package yaml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/docker/go-units"
+	"gopkg.in/yaml.v3"
+)
+
+// Restart policies a service can be declared with.
+const (
+	RestartPolicyNo            = "no"
+	RestartPolicyAlways        = "always"
+	RestartPolicyOnFailure     = "on-failure"
+	RestartPolicyUnlessStopped = "unless-stopped"
+)
+
+// RestartPolicy is a struct which represents the restart policy of a service,
+// declared as "no", "always", "unless-stopped" or "on-failure[:max-retries]".
+type RestartPolicy struct {
+	Name              string
+	MaximumRetryCount int
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (p *RestartPolicy) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.ScalarNode {
+		return fmt.Errorf("line %d: restart must be a string", value.Line)
+	}
+
+	name, retries, hasRetries := strings.Cut(value.Value, ":")
+	switch name {
+	case RestartPolicyNo, RestartPolicyAlways, RestartPolicyUnlessStopped:
+		if hasRetries {
+			return fmt.Errorf("line %d: restart policy %s does not accept a maximum retry count", value.Line, name)
+		}
+		*p = RestartPolicy{Name: name}
+	case RestartPolicyOnFailure:
+		policy := RestartPolicy{Name: name}
+		if hasRetries {
+			count, err := strconv.Atoi(retries)
+			if err != nil || count < 0 {
+				return fmt.Errorf("line %d: invalid maximum retry count %q", value.Line, retries)
+			}
+			policy.MaximumRetryCount = count
+		}
+		*p = policy
+	default:
+		return fmt.Errorf("line %d: unknown restart policy %q", value.Line, value.Value)
+	}
+
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (p RestartPolicy) MarshalYAML() (interface{}, error) {
+	if p.MaximumRetryCount > 0 {
+		return fmt.Sprintf("%s:%d", p.Name, p.MaximumRetryCount), nil
+	}
+	return p.Name, nil
+}
+
+// ByteSize is an amount of bytes declared either as a number or with
+// a unit such as "512m" or "1.5gb", where units are powers of 1024.
+type ByteSize int64
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (b *ByteSize) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.ScalarNode {
+		return fmt.Errorf("line %d: size must be a number or a string", value.Line)
+	}
+
+	size, err := units.RAMInBytes(value.Value)
+	if err != nil || size < 0 {
+		return fmt.Errorf("line %d: invalid size %q", value.Line, value.Value)
+	}
+
+	*b = ByteSize(size)
+	return nil
+}
+
+// CPUs is a fractional number of CPUs declared either as a number or as a string such as "1.5".
+type CPUs float64
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (c *CPUs) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.ScalarNode {
+		return fmt.Errorf("line %d: cpus must be a number or a string", value.Line)
+	}
+
+	cpus, err := strconv.ParseFloat(value.Value, 64)
+	if err != nil || cpus < 0 {
+		return fmt.Errorf("line %d: invalid number of cpus %q", value.Line, value.Value)
+	}
+
+	*c = CPUs(cpus)
+	return nil
+}
+
+// Deploy is a struct which represents the deploy section of a service. Only resources are supported.
+type Deploy struct {
+	Resources DeployResources `yaml:"resources,omitempty"`
+}
+
+// DeployResources is a struct which represents the resource limits and reservations of a service.
+type DeployResources struct {
+	Limits       *ResourceLimits `yaml:"limits,omitempty"`
+	Reservations *ResourceLimits `yaml:"reservations,omitempty"`
+}
+
+// ResourceLimits is a struct which represents an amount of resources.
+type ResourceLimits struct {
+	CPUs   CPUs     `yaml:"cpus,omitempty"`
+	Memory ByteSize `yaml:"memory,omitempty"`
+	Pids   int64    `yaml:"pids,omitempty"`
+}
@@ -0,0 +1,50 @@
+package yaml
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Ulimit is a struct which represents the soft and hard limits of a resource. It can be declared
+// either as a single number used for both limits or as a map with the soft and hard keys.
+type Ulimit struct {
+	Soft int64 `yaml:"soft"`
+	Hard int64 `yaml:"hard"`
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (u *Ulimit) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		var limit int64
+		if err := value.Decode(&limit); err != nil {
+			return fmt.Errorf("line %d: ulimit must be a number", value.Line)
+		}
+		*u = Ulimit{Soft: limit, Hard: limit}
+	case yaml.MappingNode:
+		type plain Ulimit
+		var limits plain
+		if err := value.Decode(&limits); err != nil {
+			return err
+		}
+		if limits.Soft > limits.Hard {
+			return fmt.Errorf("line %d: soft limit %d is greater than hard limit %d", value.Line, limits.Soft, limits.Hard)
+		}
+		*u = Ulimit(limits)
+	default:
+		return fmt.Errorf("line %d: ulimit must be a number or a map", value.Line)
+	}
+
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (u Ulimit) MarshalYAML() (interface{}, error) {
+	if u.Soft == u.Hard {
+		return u.Soft, nil
+	}
+
+	type plain Ulimit
+	return plain(u), nil
+}
@@ -0,0 +1,118 @@
+package yaml
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StringList is a list of strings which can also be declared as a single string.
+type StringList []string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (l *StringList) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		*l = StringList{value.Value}
+	case yaml.SequenceNode:
+		var items []string
+		if err := value.Decode(&items); err != nil {
+			return err
+		}
+		*l = items
+	default:
+		return fmt.Errorf("line %d: value must be a string or a list", value.Line)
+	}
+
+	return nil
+}
+
+// Mapping maps keys to string values. It can be declared either as a map or as a list of
+// key=value entries, entries without a value are mapped to an empty string.
+type Mapping map[string]string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (m *Mapping) UnmarshalYAML(value *yaml.Node) error {
+	mapping := Mapping{}
+	switch value.Kind {
+	case yaml.SequenceNode:
+		for _, item := range value.Content {
+			if item.Kind != yaml.ScalarNode {
+				return fmt.Errorf("line %d: entries must be strings", item.Line)
+			}
+
+			key, val, _ := strings.Cut(item.Value, "=")
+			if key == "" {
+				return fmt.Errorf("line %d: invalid entry %q", item.Line, item.Value)
+			}
+			mapping[key] = val
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(value.Content); i += 2 {
+			key, val := value.Content[i], value.Content[i+1]
+			if val.Kind != yaml.ScalarNode {
+				return fmt.Errorf("line %d: value of %s must be a string, number, boolean or null", val.Line, key.Value)
+			}
+
+			// Booleans and numbers keep the text they were written with.
+			if val.ShortTag() == "!!null" {
+				mapping[key.Value] = ""
+			} else {
+				mapping[key.Value] = val.Value
+			}
+		}
+	default:
+		return fmt.Errorf("line %d: value must be a list or a map", value.Line)
+	}
+
+	*m = mapping
+	return nil
+}
+
+// ExtraHosts is a list of host:ip entries added to /etc/hosts. It can be declared either as a
+// list of host:ip or host=ip entries or as a map of hosts to their IP addresses.
+type ExtraHosts []string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (h *ExtraHosts) UnmarshalYAML(value *yaml.Node) error {
+	var hosts ExtraHosts
+	switch value.Kind {
+	case yaml.SequenceNode:
+		for _, item := range value.Content {
+			if item.Kind != yaml.ScalarNode {
+				return fmt.Errorf("line %d: extra host entries must be strings", item.Line)
+			}
+
+			host, ip, ok := strings.Cut(item.Value, "=")
+			if !ok {
+				host, ip, ok = strings.Cut(item.Value, ":")
+			}
+			if !ok || host == "" || ip == "" {
+				return fmt.Errorf("line %d: invalid extra host %q, expected host:ip", item.Line, item.Value)
+			}
+			hosts = append(hosts, host+":"+ip)
+		}
+	case yaml.MappingNode:
+		var mapping map[string]string
+		if err := value.Decode(&mapping); err != nil {
+			return err
+		}
+		for host, ip := range mapping {
+			hosts = append(hosts, host+":"+ip)
+		}
+		sort.Strings(hosts)
+	default:
+		return fmt.Errorf("line %d: extra_hosts must be a list or a map", value.Line)
+	}
+
+	*h = hosts
+	return nil
+}
+
+// Logging is a struct which represents the logging driver of a service.
+type Logging struct {
+	Driver  string            `yaml:"driver,omitempty"`
+	Options map[string]string `yaml:"options,omitempty"`
+}
@@ -0,0 +1,446 @@
+package yaml
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	serviceNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+	linePrefixPattern  = regexp.MustCompile(`^line \d+: `)
+
+	composeFileType = reflect.TypeOf(ComposeFile{})
+	durationType    = reflect.TypeOf(time.Duration(0))
+	unmarshalerType = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+)
+
+// ValidationError is a problem found at a position of a compose file.
+type ValidationError struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+// Error implements error.
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Message)
+}
+
+// ValidationErrors are all the problems found in the compose files, one per line.
+type ValidationErrors []ValidationError
+
+// sort orders the errors by file, line and column.
+func (e ValidationErrors) sort() {
+	sort.SliceStable(e, func(i, j int) bool {
+		if e[i].File != e[j].File {
+			return e[i].File < e[j].File
+		}
+		if e[i].Line != e[j].Line {
+			return e[i].Line < e[j].Line
+		}
+		return e[i].Column < e[j].Column
+	})
+}
+
+// Error implements error.
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+type validator struct {
+	file   string
+	errors ValidationErrors
+}
+
+func (v *validator) report(node *yaml.Node, format string, args ...interface{}) {
+	v.errors = append(v.errors, ValidationError{
+		File:    v.file,
+		Line:    node.Line,
+		Column:  node.Column,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// validateDocument checks the document against the compose model and reports unknown keys,
+// values of the wrong type, invalid project and service names and invalid service attributes.
+func validateDocument(file string, document *yaml.Node) ValidationErrors {
+	if document.Kind != yaml.DocumentNode || len(document.Content) == 0 {
+		return nil
+	}
+
+	v := &validator{file: file}
+	root := document.Content[0]
+	v.validate(root, composeFileType, "")
+
+	if name := mappingValue(root, "name"); name != nil && name.Kind == yaml.ScalarNode && !projectNamePattern.MatchString(name.Value) {
+		v.report(name, "invalid project name %q: %s", name.Value, projectNameRule)
+	}
+
+	if services := mappingValue(root, "services"); services != nil && services.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(services.Content); i += 2 {
+			if name := services.Content[i]; !serviceNamePattern.MatchString(name.Value) {
+				v.report(name, "invalid service name %q: it must contain only letters, digits, dots, dashes and underscores and start with a letter or digit", name.Value)
+			}
+		}
+	}
+
+	return v.errors
+}
+
+// definitions are the names declared in the top level sections of all the compose files.
+type definitions struct {
+	services map[string]bool
+	networks map[string]bool
+	volumes  map[string]bool
+}
+
+func collectDefinitions(documents []*yaml.Node) definitions {
+	defined := definitions{
+		services: make(map[string]bool),
+		networks: map[string]bool{DefaultNetwork: true},
+		volumes:  make(map[string]bool),
+	}
+
+	for _, document := range documents {
+		if document.Kind != yaml.DocumentNode || len(document.Content) == 0 {
+			continue
+		}
+
+		root := document.Content[0]
+		for _, name := range referenceNames(mappingValue(root, "services")) {
+			defined.services[name.Value] = true
+		}
+		for _, name := range referenceNames(mappingValue(root, "networks")) {
+			defined.networks[name.Value] = true
+		}
+		for _, name := range referenceNames(mappingValue(root, "volumes")) {
+			defined.volumes[name.Value] = true
+		}
+	}
+
+	return defined
+}
+
+// validateReferences reports the networks, volumes and services the services of the document
+// refer to which are not defined by any of the compose files.
+func validateReferences(file string, document *yaml.Node, defined definitions) ValidationErrors {
+	if document.Kind != yaml.DocumentNode || len(document.Content) == 0 {
+		return nil
+	}
+
+	services := mappingValue(document.Content[0], "services")
+	if services == nil || services.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	v := &validator{file: file}
+	for i := 0; i+1 < len(services.Content); i += 2 {
+		name, service := services.Content[i].Value, services.Content[i+1]
+
+		for _, network := range referenceNames(mappingValue(service, "networks")) {
+			if !defined.networks[network.Value] {
+				v.report(network, "service %s refers to undefined network %s", name, network.Value)
+			}
+		}
+
+		for _, dependency := range referenceNames(mappingValue(service, "depends_on")) {
+			if !defined.services[dependency.Value] {
+				v.report(dependency, "service %s depends on undefined service %s", name, dependency.Value)
+			}
+		}
+
+		if volumes := mappingValue(service, "volumes"); volumes != nil && volumes.Kind == yaml.SequenceNode {
+			for _, item := range volumes.Content {
+				if source, ok := namedVolumeSource(item); ok && !defined.volumes[source] {
+					v.report(item, "service %s refers to undefined volume %s", name, source)
+				}
+			}
+		}
+	}
+
+	return v.errors
+}
+
+// referenceNames returns the names of a section declared either as a list or as a mapping.
+func referenceNames(node *yaml.Node) []*yaml.Node {
+	if node == nil {
+		return nil
+	}
+
+	var names []*yaml.Node
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if item.Kind == yaml.ScalarNode {
+				names = append(names, item)
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i < len(node.Content); i += 2 {
+			names = append(names, node.Content[i])
+		}
+	}
+	return names
+}
+
+// namedVolumeSource returns the name of the volume mounted by a service volume entry, if it mounts a named volume.
+// Invalid entries are reported by validateDocument.
+func namedVolumeSource(item *yaml.Node) (string, bool) {
+	var volume ServiceVolume
+	switch item.Kind {
+	case yaml.ScalarNode:
+		var err error
+		if volume, err = parseShortVolume(item.Value); err != nil {
+			return "", false
+		}
+	case yaml.MappingNode:
+		if err := item.Decode(&volume); err != nil {
+			return "", false
+		}
+	default:
+		return "", false
+	}
+
+	return volume.Source, volume.Type == VolumeTypeVolume && volume.Source != ""
+}
+
+// validateServices reports the services of the merged document which neither define an image nor a build,
+// and those pulled with the build policy without a build. The files the service keys were read from are
+// used to locate the services.
+func validateServices(document *yaml.Node, files map[*yaml.Node]string) ValidationErrors {
+	if document.Kind != yaml.DocumentNode || len(document.Content) == 0 {
+		return nil
+	}
+
+	services := mappingValue(document.Content[0], "services")
+	if services == nil || services.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var errs ValidationErrors
+	for i := 0; i+1 < len(services.Content); i += 2 {
+		name, service := services.Content[i], services.Content[i+1]
+		if service.Kind != yaml.MappingNode {
+			continue
+		}
+
+		image, build := mappingValue(service, "image"), mappingValue(service, "build")
+		hasBuild := build != nil && build.ShortTag() != "!!null"
+		if (image == nil || image.Value == "") && !hasBuild {
+			errs = append(errs, ValidationError{
+				File:    files[name],
+				Line:    name.Line,
+				Column:  name.Column,
+				Message: fmt.Sprintf("service %s must define an image or a build", name.Value),
+			})
+		}
+
+		if pullPolicy := mappingValue(service, "pull_policy"); pullPolicy != nil && pullPolicy.Value == PullPolicyBuild && !hasBuild {
+			errs = append(errs, ValidationError{
+				File:    files[name],
+				Line:    name.Line,
+				Column:  name.Column,
+				Message: fmt.Sprintf("service %s has pull policy %s but does not define a build", name.Value, PullPolicyBuild),
+			})
+		}
+	}
+
+	return errs
+}
+
+func (v *validator) validate(node *yaml.Node, t reflect.Type, path string) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if node.ShortTag() == "!!null" {
+		return
+	}
+
+	if reflect.PtrTo(t).Implements(unmarshalerType) {
+		v.validateUnmarshaler(node, t, path)
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			v.report(node, "%s must be a mapping", path)
+			return
+		}
+		v.validateFields(node, t, path)
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			v.report(node, "%s must be a mapping", path)
+			return
+		}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			v.validate(node.Content[i+1], t.Elem(), joinPath(path, node.Content[i].Value))
+		}
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			v.report(node, "%s must be a list", path)
+			return
+		}
+
+		for i, item := range node.Content {
+			v.validate(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+		}
+	default:
+		if node.Kind != yaml.ScalarNode || node.Decode(reflect.New(t).Interface()) != nil {
+			v.report(node, "%s must be %s", path, describeType(t))
+		}
+	}
+}
+
+// validateFields reports the unknown keys of the mapping and validates the values of the known ones.
+func (v *validator) validateFields(node *yaml.Node, t reflect.Type, path string) {
+	fields := yamlFields(t)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if strings.HasPrefix(key.Value, "x-") || (t == composeFileType && key.Value == "version") {
+			continue
+		}
+
+		fieldType, ok := fields[key.Value]
+		if !ok {
+			v.report(key, "unknown key %q in %s", key.Value, describePath(path))
+			continue
+		}
+		v.validate(value, fieldType, joinPath(path, key.Value))
+	}
+}
+
+// validateUnmarshaler decodes the node into a type with its own syntax, e.g. ports or volumes.
+// Items of lists of definitions are decoded one by one to report every invalid item at its own position and
+// the entries and definitions written in the long syntax are validated against the underlying type.
+// Every item is checked by a single pass so that a problem is not reported twice.
+func (v *validator) validateUnmarshaler(node *yaml.Node, t reflect.Type, path string) {
+	// Definitions written as a mapping are checked key by key first, decoding
+	// them would only report the first problem without its position.
+	if t.Kind() == reflect.Struct && node.Kind == yaml.MappingNode {
+		reported := len(v.errors)
+		v.validateFields(node, t, path)
+		if len(v.errors) > reported {
+			return
+		}
+	}
+
+	reported := len(v.errors)
+	switch {
+	case t.Kind() == reflect.Slice && node.Kind == yaml.SequenceNode:
+		for i, item := range node.Content {
+			itemReported := len(v.errors)
+			if item.Kind == yaml.MappingNode {
+				v.validate(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+			}
+			if len(v.errors) > itemReported || t.Elem().Kind() != reflect.Struct {
+				continue
+			}
+
+			sequence := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{item}}
+			if err := sequence.Decode(reflect.New(t).Interface()); err != nil {
+				v.report(item, "%s: %s", path, decodeErrorMessage(err))
+			}
+		}
+	case t.Kind() == reflect.Map && node.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if value := node.Content[i+1]; value.Kind == yaml.MappingNode {
+				v.validate(value, t.Elem(), joinPath(path, node.Content[i].Value))
+			}
+		}
+	}
+	if len(v.errors) > reported {
+		return
+	}
+
+	if err := node.Decode(reflect.New(t).Interface()); err != nil {
+		v.report(node, "%s: %s", path, decodeErrorMessage(err))
+	}
+}
+
+// yamlFields returns the types of the struct fields by their YAML keys.
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	if index := mappingIndex(node, key); index >= 0 {
+		return node.Content[index+1]
+	}
+	return nil
+}
+
+func decodeErrorMessage(err error) string {
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		messages := make([]string, 0, len(typeErr.Errors))
+		for _, message := range typeErr.Errors {
+			messages = append(messages, linePrefixPattern.ReplaceAllString(message, ""))
+		}
+		return strings.Join(messages, ", ")
+	}
+	return linePrefixPattern.ReplaceAllString(err.Error(), "")
+}
+
+func describeType(t reflect.Type) string {
+	if t == durationType {
+		return "a duration"
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	default:
+		return "a string"
+	}
+}
+
+func describePath(path string) string {
+	if path == "" {
+		return "the top level"
+	}
+	return path
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
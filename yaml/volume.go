@@ -0,0 +1,202 @@
+package yaml
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Volume mount types supported by services.
+const (
+	VolumeTypeVolume = "volume"
+	VolumeTypeBind   = "bind"
+	VolumeTypeTmpfs  = "tmpfs"
+)
+
+// Volume is a struct which represents a named volume declared
+// in the top-level volumes section of a composer YAML file.
+type Volume struct {
+	Name       string            `yaml:"name,omitempty"`
+	Driver     string            `yaml:"driver,omitempty"`
+	DriverOpts map[string]string `yaml:"driver_opts,omitempty"`
+	External   bool              `yaml:"external,omitempty"`
+	Labels     Mapping           `yaml:"labels,omitempty"`
+}
+
+// VolumeName returns the name of the docker volume created for the volume declared under key.
+// Volumes are prefixed with the project name unless they are named explicitly or external.
+func (c *ComposeFile) VolumeName(key string) string {
+	volume := c.Volumes[key]
+	if volume.Name != "" {
+		return volume.Name
+	}
+
+	if volume.External {
+		return key
+	}
+
+	return fmt.Sprintf("%s_%s", c.Name, key)
+}
+
+// ServiceVolume is a struct which represents a volume or a bind mount of a service.
+type ServiceVolume struct {
+	Type     string               `yaml:"type"`
+	Source   string               `yaml:"source,omitempty"`
+	Target   string               `yaml:"target"`
+	ReadOnly bool                 `yaml:"read_only,omitempty"`
+	Bind     *ServiceVolumeBind   `yaml:"bind,omitempty"`
+	Volume   *ServiceVolumeVolume `yaml:"volume,omitempty"`
+}
+
+// ServiceVolumeBind holds the options specific to bind mounts.
+type ServiceVolumeBind struct {
+	Propagation string `yaml:"propagation,omitempty"`
+}
+
+// ServiceVolumeVolume holds the options specific to volume mounts.
+type ServiceVolumeVolume struct {
+	NoCopy bool `yaml:"nocopy,omitempty"`
+}
+
+// ServiceVolumes is a list of service volumes which can be declared
+// with the short ("./data:/var/lib/mysql:ro") or the long syntax.
+type ServiceVolumes []ServiceVolume
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (v *ServiceVolumes) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.SequenceNode {
+		return fmt.Errorf("line %d: volumes must be a list", value.Line)
+	}
+
+	var volumes ServiceVolumes
+	for _, item := range value.Content {
+		switch item.Kind {
+		case yaml.ScalarNode:
+			volume, err := parseShortVolume(item.Value)
+			if err != nil {
+				return fmt.Errorf("line %d: %s", item.Line, err)
+			}
+			volumes = append(volumes, volume)
+		case yaml.MappingNode:
+			var volume ServiceVolume
+			if err := item.Decode(&volume); err != nil {
+				return err
+			}
+			if err := validateLongVolume(volume); err != nil {
+				return fmt.Errorf("line %d: %s", item.Line, err)
+			}
+			volumes = append(volumes, volume)
+		default:
+			return fmt.Errorf("line %d: invalid volume definition", item.Line)
+		}
+	}
+
+	*v = volumes
+	return nil
+}
+
+func parseShortVolume(spec string) (ServiceVolume, error) {
+	parts := strings.Split(spec, ":")
+	if len(parts) > 3 || spec == "" {
+		return ServiceVolume{}, fmt.Errorf("invalid volume %q", spec)
+	}
+
+	// A single path declares an anonymous volume.
+	if len(parts) == 1 {
+		return ServiceVolume{Type: VolumeTypeVolume, Target: parts[0]}, nil
+	}
+
+	volume := ServiceVolume{Source: parts[0], Target: parts[1]}
+	if volume.Source == "" || volume.Target == "" {
+		return ServiceVolume{}, fmt.Errorf("invalid volume %q", spec)
+	}
+
+	volume.Type = VolumeTypeVolume
+	if isPath(volume.Source) {
+		volume.Type = VolumeTypeBind
+	}
+
+	if len(parts) == 3 {
+		if err := applyVolumeModes(&volume, parts[2]); err != nil {
+			return ServiceVolume{}, fmt.Errorf("invalid volume %q: %s", spec, err)
+		}
+	}
+
+	return volume, nil
+}
+
+func applyVolumeModes(volume *ServiceVolume, modes string) error {
+	for _, mode := range strings.Split(modes, ",") {
+		switch mode {
+		case "ro":
+			volume.ReadOnly = true
+		case "rw", "z", "Z", "cached", "delegated", "consistent":
+		case "nocopy":
+			if volume.Type != VolumeTypeVolume {
+				return fmt.Errorf("nocopy is only supported by named volumes")
+			}
+			volume.Volume = &ServiceVolumeVolume{NoCopy: true}
+		case "shared", "rshared", "slave", "rslave", "private", "rprivate":
+			if volume.Type != VolumeTypeBind {
+				return fmt.Errorf("propagation is only supported by bind mounts")
+			}
+			volume.Bind = &ServiceVolumeBind{Propagation: mode}
+		default:
+			return fmt.Errorf("unknown mode %q", mode)
+		}
+	}
+
+	return nil
+}
+
+func validateLongVolume(volume ServiceVolume) error {
+	switch volume.Type {
+	case VolumeTypeVolume, VolumeTypeTmpfs:
+	case VolumeTypeBind:
+		if volume.Source == "" {
+			return fmt.Errorf("bind mount source is required")
+		}
+	default:
+		return fmt.Errorf("unsupported volume type %q", volume.Type)
+	}
+
+	if volume.Target == "" {
+		return fmt.Errorf("volume target is required")
+	}
+
+	return nil
+}
+
+func isPath(source string) bool {
+	return strings.HasPrefix(source, ".") || strings.HasPrefix(source, "/") || strings.HasPrefix(source, "~")
+}
+
+// resolveBindPaths makes the bind mount sources absolute, relative
+// paths being resolved against the directory of the compose file.
+func resolveBindPaths(volumes ServiceVolumes, workingDir string) error {
+	for i, volume := range volumes {
+		if volume.Type != VolumeTypeBind {
+			continue
+		}
+
+		source := volume.Source
+		if source == "~" || strings.HasPrefix(source, "~/") {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return err
+			}
+			source = filepath.Join(home, strings.TrimPrefix(source, "~"))
+		}
+
+		if !filepath.IsAbs(source) {
+			source = filepath.Join(workingDir, source)
+		}
+
+		volumes[i].Source = filepath.Clean(source)
+	}
+
+	return nil
+}
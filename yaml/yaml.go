@@ -1,35 +1,326 @@
 package yaml
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
-// Services is a struct which represents the composer YAML file.
-type Services struct {
+// ComposeFileNames are the names of the compose files looked up by FindComposeFile, in order of preference.
+var ComposeFileNames = []string{
+	"compose.yaml",
+	"compose.yml",
+	"docker-compose.yaml",
+	"docker-compose.yml",
+}
+
+// OverrideFileNames are the names of the files which are merged over the compose file found in the same directory.
+var OverrideFileNames = []string{
+	"compose.override.yaml",
+	"compose.override.yml",
+	"docker-compose.override.yaml",
+	"docker-compose.override.yml",
+}
+
+var projectNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+const projectNameRule = "it must contain only lowercase letters, digits, dashes and underscores and start with a letter or digit"
+
+// ComposeFile is a struct which represents the composer YAML file.
+type ComposeFile struct {
+	Name     string             `yaml:"name,omitempty"`
 	Services map[string]Service `yaml:"services"`
+	Volumes  map[string]Volume  `yaml:"volumes,omitempty"`
+	Networks map[string]Network `yaml:"networks,omitempty"`
+	// ConfigFiles are the absolute paths of the files the compose file was parsed from.
+	ConfigFiles []string `yaml:"-"`
 }
 
 // Service is a struct which represents a service in a composer YAML file.
 type Service struct {
-	Image           string            `yaml:"image"`
-	EnvironmentVars map[string]string `yaml:"environment"`
+	Image           string              `yaml:"image,omitempty"`
+	Build           *Build              `yaml:"build,omitempty"`
+	PullPolicy      PullPolicy          `yaml:"pull_policy,omitempty"`
+	Command         ShellCommand        `yaml:"command,omitempty"`
+	Entrypoint      ShellCommand        `yaml:"entrypoint,omitempty"`
+	WorkingDir      string              `yaml:"working_dir,omitempty"`
+	User            string              `yaml:"user,omitempty"`
+	Hostname        string              `yaml:"hostname,omitempty"`
+	Tty             bool                `yaml:"tty,omitempty"`
+	StdinOpen       bool                `yaml:"stdin_open,omitempty"`
+	Restart         *RestartPolicy      `yaml:"restart,omitempty"`
+	MemLimit        ByteSize            `yaml:"mem_limit,omitempty"`
+	MemReservation  ByteSize            `yaml:"mem_reservation,omitempty"`
+	CPUs            CPUs                `yaml:"cpus,omitempty"`
+	CPUShares       int64               `yaml:"cpu_shares,omitempty"`
+	PidsLimit       int64               `yaml:"pids_limit,omitempty"`
+	ShmSize         ByteSize            `yaml:"shm_size,omitempty"`
+	Deploy          *Deploy             `yaml:"deploy,omitempty"`
+	Privileged      bool                `yaml:"privileged,omitempty"`
+	CapAdd          []string            `yaml:"cap_add,omitempty"`
+	CapDrop         []string            `yaml:"cap_drop,omitempty"`
+	ReadOnly        bool                `yaml:"read_only,omitempty"`
+	SecurityOpt     []string            `yaml:"security_opt,omitempty"`
+	Tmpfs           StringList          `yaml:"tmpfs,omitempty"`
+	Sysctls         Mapping             `yaml:"sysctls,omitempty"`
+	Ulimits         map[string]Ulimit   `yaml:"ulimits,omitempty"`
+	Labels          Mapping             `yaml:"labels,omitempty"`
+	ExtraHosts      ExtraHosts          `yaml:"extra_hosts,omitempty"`
+	DNS             StringList          `yaml:"dns,omitempty"`
+	DNSSearch       StringList          `yaml:"dns_search,omitempty"`
+	Domainname      string              `yaml:"domainname,omitempty"`
+	Logging         *Logging            `yaml:"logging,omitempty"`
+	StopSignal      string              `yaml:"stop_signal,omitempty"`
+	StopGracePeriod time.Duration       `yaml:"stop_grace_period,omitempty"`
+	EnvironmentVars ServiceEnvironment  `yaml:"environment,omitempty"`
+	EnvFiles        ServiceEnvFiles     `yaml:"env_file,omitempty"`
+	Ports           ServicePorts        `yaml:"ports,omitempty"`
+	Volumes         ServiceVolumes      `yaml:"volumes,omitempty"`
+	DependsOn       ServiceDependencies `yaml:"depends_on,omitempty"`
+	HealthCheck     *HealthCheck        `yaml:"healthcheck,omitempty"`
+	Networks        ServiceNetworks     `yaml:"networks,omitempty"`
+}
+
+// ParseComposeFile parses a composer YAML file and returns its services and volumes.
+func ParseComposeFile(path string) (*ComposeFile, error) {
+	return ParseComposeFiles(path)
+}
+
+// ParseComposeFiles parses and merges the given composer YAML files, later files overriding
+// earlier ones. Relative paths and the .env file are resolved from the directory of the first file.
+func ParseComposeFiles(paths ...string) (*ComposeFile, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no compose file given")
+	}
+
+	absPaths := make([]string, 0, len(paths))
+	for _, path := range paths {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+		absPaths = append(absPaths, absPath)
+	}
+	projectDir := filepath.Dir(absPaths[0])
+
+	lookup, err := environmentLookup(projectDir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s file: %s", EnvFileName, err)
+	}
+
+	var documents []*yaml.Node
+	var validationErrs ValidationErrors
+	serviceFiles := make(map[*yaml.Node]string)
+	for _, path := range paths {
+		document, err := readComposeDocument(path, lookup)
+		if err != nil {
+			return nil, err
+		}
+
+		validationErrs = append(validationErrs, validateDocument(path, document)...)
+		recordServiceFile(document, path, serviceFiles)
+		documents = append(documents, document)
+	}
+
+	// References are checked before merging as they may point to definitions of any of the files.
+	defined := collectDefinitions(documents)
+	var merged *yaml.Node
+	for i, document := range documents {
+		validationErrs = append(validationErrs, validateReferences(paths[i], document, defined)...)
+
+		if merged == nil {
+			merged = document
+		} else {
+			merged = mergeNodes(merged, document, nil)
+		}
+	}
+
+	validationErrs = append(validationErrs, validateServices(merged, serviceFiles)...)
+	if len(validationErrs) > 0 {
+		validationErrs.sort()
+		return nil, validationErrs
+	}
+
+	composeFile := &ComposeFile{}
+	if err = merged.Decode(composeFile); err != nil {
+		return nil, err
+	}
+
+	composeFile.ConfigFiles = absPaths
+	if composeFile.Name == "" {
+		composeFile.Name = normalizeProjectName(filepath.Base(projectDir))
+	}
+	// Names declared in the files are validated with the documents, only a name derived from the directory can be invalid here.
+	if !projectNamePattern.MatchString(composeFile.Name) {
+		return nil, fmt.Errorf("no valid project name can be derived from the directory %s: declare the top-level name", projectDir)
+	}
+
+	for name, service := range composeFile.Services {
+		if err = resolveBindPaths(service.Volumes, projectDir); err != nil {
+			return nil, err
+		}
+
+		if err = resolveEnvFiles(&service, projectDir); err != nil {
+			return nil, fmt.Errorf("service %s: %s", name, err)
+		}
+		resolveBuildContext(service.Build, projectDir)
+		composeFile.Services[name] = service
+	}
+
+	return composeFile, nil
 }
 
-// ParseComposeFile parses a composer YAML file and returns a map of services.
-func ParseComposeFile(path string) (map[string]Service, error) {
+// FindComposeFile returns the path of the first compose file found in the directory or the closest of its parents.
+func FindComposeFile(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for current := dir; ; current = filepath.Dir(current) {
+		if path, ok := findFile(current, ComposeFileNames); ok {
+			return path, nil
+		}
+		if filepath.Dir(current) == current {
+			return "", fmt.Errorf("no compose file (%s) found in %s or its parents", strings.Join(ComposeFileNames, ", "), dir)
+		}
+	}
+}
+
+// FindOverrideFile returns the path of the first override file found in the directory.
+func FindOverrideFile(dir string) (string, bool) {
+	return findFile(dir, OverrideFileNames)
+}
+
+func findFile(dir string, names []string) (string, bool) {
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// recordServiceFile maps the service keys of the document to the file they were read from.
+func recordServiceFile(document *yaml.Node, path string, files map[*yaml.Node]string) {
+	if document.Kind != yaml.DocumentNode || len(document.Content) == 0 {
+		return
+	}
+
+	if services := mappingValue(document.Content[0], "services"); services != nil && services.Kind == yaml.MappingNode {
+		for i := 0; i < len(services.Content); i += 2 {
+			files[services.Content[i]] = path
+		}
+	}
+}
+
+// readComposeDocument reads a composer YAML file and interpolates its variables.
+func readComposeDocument(path string, lookup lookupFunc) (*yaml.Node, error) {
 	yamlFile, err := ioutil.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("error reading YAML file: %s", err)
 	}
 
-	yamlServices := Services{}
-	err = yaml.Unmarshal(yamlFile, &yamlServices)
+	document := &yaml.Node{}
+	if err = yaml.Unmarshal(yamlFile, document); err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+
+	if err = interpolateNode(document, "", lookup); err != nil {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+
+	return document, nil
+}
+
+// YAML returns the compose file as a normalized YAML document. Dollar signs left by the
+// interpolation are escaped as "$$" so the document can be parsed again.
+func (c *ComposeFile) YAML() ([]byte, error) {
+	var node yaml.Node
+	if err := node.Encode(c); err != nil {
+		return nil, err
+	}
+	escapeDollars(&node)
+
+	var document bytes.Buffer
+	encoder := yaml.NewEncoder(&document)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return document.Bytes(), nil
+}
+
+// JSON returns the compose file as an indented JSON document using the same keys and escaping as YAML.
+func (c *ComposeFile) JSON() ([]byte, error) {
+	document, err := c.YAML()
 	if err != nil {
 		return nil, err
 	}
 
-	return yamlServices.Services, nil
+	var model map[string]interface{}
+	if err = yaml.Unmarshal(document, &model); err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(model, "", "  ")
+}
+
+func escapeDollars(node *yaml.Node) {
+	if node.Kind == yaml.ScalarNode {
+		node.Value = strings.ReplaceAll(node.Value, "$", "$$")
+	}
+	for _, child := range node.Content {
+		escapeDollars(child)
+	}
+}
+
+// ContainerName returns the name of the container created for the service, prefixed with the project name.
+func (c *ComposeFile) ContainerName(service string) string {
+	return fmt.Sprintf("%s-%s", c.Name, service)
+}
+
+// ImageName returns the image of the service. Services which are built without
+// declaring an image are tagged with a name derived from the project and the service.
+func (c *ComposeFile) ImageName(service string) string {
+	if image := c.Services[service].Image; image != "" {
+		return image
+	}
+	return fmt.Sprintf("%s-%s", c.Name, service)
+}
+
+// SetProjectName overrides the project name of the compose file.
+func (c *ComposeFile) SetProjectName(name string) error {
+	if !projectNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid project name %q: %s", name, projectNameRule)
+	}
+
+	c.Name = name
+
+	return nil
+}
+
+// normalizeProjectName lowercases the name and drops the characters docker does not accept in resource names.
+func normalizeProjectName(name string) string {
+	var normalized strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' || r == '-' {
+			normalized.WriteRune(r)
+		}
+	}
+
+	return strings.TrimLeft(normalized.String(), "_-")
 }
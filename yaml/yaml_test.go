@@ -1,7 +1,10 @@
 package yaml_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -15,18 +18,39 @@ const (
 func TestParseComposeFile_ThenSuccess(t *testing.T) {
 	// Arrange
 
+	absPath, _ := filepath.Abs(path)
+
 	// Act
 	result, err := yaml.ParseComposeFile(path)
 
 	// Assert
-	want := map[string]yaml.Service{
-		"nginx": {Image: "nginx:alpine"},
-		"db": {
-			Image:           "mysql:latest",
-			EnvironmentVars: map[string]string{"MYSQL_ALLOW_EMPTY_PASSWORD": "true"},
+	want := &yaml.ComposeFile{
+		Name:        "docker-cli",
+		ConfigFiles: []string{absPath},
+		Services: map[string]yaml.Service{
+			"nginx": {
+				Image: "nginx:alpine",
+				Ports: yaml.ServicePorts{{Target: 80, Published: "8080", Protocol: "tcp"}},
+			},
+			"db": {
+				Image:           "mysql:latest",
+				EnvironmentVars: yaml.ServiceEnvironment{"MYSQL_ALLOW_EMPTY_PASSWORD": "true"},
+				Volumes:         yaml.ServiceVolumes{{Type: "volume", Source: "db-data", Target: "/var/lib/mysql"}},
+				HealthCheck: &yaml.HealthCheck{
+					Test:     yaml.HealthCheckTest{"CMD", "mysqladmin", "ping", "-h", "localhost"},
+					Interval: 10 * time.Second,
+					Timeout:  5 * time.Second,
+					Retries:  5,
+				},
+			},
+			"cache": {Image: "memcached"},
+			"wordpress": {
+				Image:     "wordpress:6.0",
+				Ports:     yaml.ServicePorts{{Target: 80, Published: "8000", Protocol: "tcp"}},
+				DependsOn: yaml.ServiceDependencies{"db": {Condition: "service_healthy"}},
+			},
 		},
-		"cache":     {Image: "memcached"},
-		"wordpress": {Image: "wordpress:6.0"},
+		Volumes: map[string]yaml.Volume{"db-data": {}},
 	}
 
 	assert.NoError(t, err)
@@ -43,3 +67,812 @@ func TestParseComposeFile_ThenFailure(t *testing.T) {
 	assert.Error(t, err)
 	assert.Empty(t, result)
 }
+
+func TestParseComposeFile_WhenPortsArePublished_ThenSuccess(t *testing.T) {
+	// Arrange
+
+	// Act
+	result, err := yaml.ParseComposeFile("testdata/ports-compose.yaml")
+
+	// Assert
+	want := yaml.ServicePorts{
+		{Target: 3000, Protocol: "tcp"},
+		{Target: 80, Published: "8080", Protocol: "tcp"},
+		{Target: 53, Published: "5353", HostIP: "127.0.0.1", Protocol: "udp"},
+		{Target: 8080, Published: "9090", Protocol: "tcp"},
+		{Target: 8081, Published: "9091", Protocol: "tcp"},
+		{Target: 443, Published: "8443", HostIP: "0.0.0.0", Protocol: "tcp", Mode: "host"},
+	}
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, want, result.Services["web"].Ports)
+}
+
+func TestParseComposeFile_WhenPortIsInvalid_ThenFailure(t *testing.T) {
+	// Arrange
+
+	// Act
+	result, err := yaml.ParseComposeFile("testdata/invalid-ports-compose.yaml")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Empty(t, result)
+}
+
+func TestParseComposeFile_WhenVolumesAreMounted_ThenSuccess(t *testing.T) {
+	// Arrange
+	workingDir, err := filepath.Abs("testdata")
+	assert.NoError(t, err)
+
+	// Act
+	result, err := yaml.ParseComposeFile("testdata/volumes-compose.yaml")
+
+	// Assert
+	wantServiceVolumes := yaml.ServiceVolumes{
+		{Type: "volume", Source: "data", Target: "/var/lib/mysql"},
+		{Type: "bind", Source: filepath.Join(workingDir, "config"), Target: "/etc/mysql/conf.d", ReadOnly: true},
+		{Type: "bind", Source: "/var/run/mysqld", Target: "/run/mysqld", Bind: &yaml.ServiceVolumeBind{Propagation: "rshared"}},
+		{Type: "volume", Target: "/tmp/cache"},
+		{Type: "volume", Source: "logs", Target: "/var/log/mysql", Volume: &yaml.ServiceVolumeVolume{NoCopy: true}},
+		{Type: "bind", Source: filepath.Join(workingDir, "backups"), Target: "/backups", ReadOnly: true},
+	}
+	wantVolumes := map[string]yaml.Volume{
+		"data": {},
+		"logs": {Name: "shared-logs", Driver: "local", DriverOpts: map[string]string{"type": "tmpfs", "device": "tmpfs"}},
+	}
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, wantServiceVolumes, result.Services["db"].Volumes)
+	assert.EqualValues(t, wantVolumes, result.Volumes)
+}
+
+func TestParseComposeFile_WhenVolumeIsUndefined_ThenFailure(t *testing.T) {
+	// Arrange
+	file := "testdata/undefined-volume-compose.yaml"
+
+	// Act
+	result, err := yaml.ParseComposeFile(file)
+
+	// Assert
+	want := yaml.ValidationErrors{
+		{File: file, Line: 5, Column: 9, Message: "service db refers to undefined volume data"},
+	}
+
+	assert.Equal(t, want, err)
+	assert.Empty(t, result)
+}
+
+func TestParseComposeFile_WhenServicesDependOnEachOther_ThenSuccess(t *testing.T) {
+	// Arrange
+
+	// Act
+	result, err := yaml.ParseComposeFile("testdata/dependencies-compose.yaml")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.EqualValues(t, yaml.ServiceDependencies{
+		"db":    {Condition: "service_healthy"},
+		"cache": {Condition: "service_started"},
+	}, result.Services["app"].DependsOn)
+	assert.EqualValues(t, yaml.ServiceDependencies{
+		"app":     {Condition: "service_started"},
+		"migrate": {Condition: "service_completed_successfully"},
+	}, result.Services["proxy"].DependsOn)
+}
+
+func TestParseComposeFile_WhenDependencyIsUndefined_ThenFailure(t *testing.T) {
+	// Arrange
+	file := "testdata/undefined-dependency-compose.yaml"
+
+	// Act
+	result, err := yaml.ParseComposeFile(file)
+
+	// Assert
+	want := yaml.ValidationErrors{
+		{File: file, Line: 5, Column: 9, Message: "service app depends on undefined service db"},
+	}
+
+	assert.Equal(t, want, err)
+	assert.Empty(t, result)
+}
+
+func TestResolveDependencies_ThenSuccess(t *testing.T) {
+	// Arrange
+	composeFile, err := yaml.ParseComposeFile("testdata/dependencies-compose.yaml")
+	assert.NoError(t, err)
+
+	// Act
+	result, err := composeFile.ResolveDependencies([]string{"proxy", "cache"})
+
+	// Assert
+	want := []string{"cache", "db", "app", "migrate", "proxy"}
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, want, result)
+}
+
+func TestResolveDependencies_WhenDependenciesAreCyclic_ThenFailure(t *testing.T) {
+	// Arrange
+	composeFile, err := yaml.ParseComposeFile("testdata/cyclic-dependencies-compose.yaml")
+	assert.NoError(t, err)
+
+	// Act
+	result, err := composeFile.ResolveDependencies([]string{"a"})
+
+	// Assert
+	assert.EqualError(t, err, "dependency cycle detected: a -> b -> c -> a")
+	assert.Empty(t, result)
+}
+
+func TestParseComposeFile_WhenHealthChecksAreDefined_ThenSuccess(t *testing.T) {
+	// Arrange
+
+	// Act
+	result, err := yaml.ParseComposeFile("testdata/healthcheck-compose.yaml")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.EqualValues(t, &yaml.HealthCheck{
+		Test:        yaml.HealthCheckTest{"CMD-SHELL", "curl -f http://localhost || exit 1"},
+		Interval:    90 * time.Second,
+		Timeout:     10 * time.Second,
+		Retries:     3,
+		StartPeriod: 40 * time.Second,
+	}, result.Services["web"].HealthCheck)
+	assert.EqualValues(t, &yaml.HealthCheck{Disable: true}, result.Services["worker"].HealthCheck)
+}
+
+func TestParseComposeFile_WhenHealthCheckTestIsInvalid_ThenFailure(t *testing.T) {
+	// Arrange
+
+	// Act
+	result, err := yaml.ParseComposeFile("testdata/invalid-healthcheck-compose.yaml")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Empty(t, result)
+}
+
+func TestParseComposeFile_WhenNetworksAreDefined_ThenSuccess(t *testing.T) {
+	// Arrange
+
+	// Act
+	result, err := yaml.ParseComposeFile("testdata/networks-compose.yaml")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "testdata", result.Name)
+	assert.EqualValues(t, yaml.ServiceNetworks{
+		"front": {},
+		"back":  {Aliases: []string{"api"}},
+	}, result.Services["app"].Networks)
+	assert.EqualValues(t, yaml.ServiceNetworks{"back": {}}, result.Services["db"].Networks)
+	assert.Equal(t, "testdata_default", result.NetworkName("default"))
+	assert.Equal(t, "testdata_front", result.NetworkName("front"))
+	assert.Equal(t, "shared-backend", result.NetworkName("back"))
+}
+
+func TestComposeFile_ResourceNames(t *testing.T) {
+	// Arrange
+	composeFile := &yaml.ComposeFile{
+		Name: "project",
+		Volumes: map[string]yaml.Volume{
+			"data":     {},
+			"named":    {Name: "custom-data"},
+			"external": {External: true},
+		},
+		Networks: map[string]yaml.Network{
+			"external": {External: true},
+		},
+	}
+
+	// Act & Assert
+	assert.Equal(t, "project-db", composeFile.ContainerName("db"))
+	assert.Equal(t, "project_data", composeFile.VolumeName("data"))
+	assert.Equal(t, "custom-data", composeFile.VolumeName("named"))
+	assert.Equal(t, "external", composeFile.VolumeName("external"))
+	assert.Equal(t, "project_default", composeFile.NetworkName("default"))
+	assert.Equal(t, "external", composeFile.NetworkName("external"))
+}
+
+func TestParseComposeFile_WhenProjectNameIsInvalid_ThenFailure(t *testing.T) {
+	// Arrange
+	file := "testdata/invalid-project-name-compose.yaml"
+
+	// Act
+	result, err := yaml.ParseComposeFile(file)
+
+	// Assert
+	want := yaml.ValidationErrors{
+		{File: file, Line: 1, Column: 7, Message: `invalid project name "My App": it must contain only lowercase letters, digits, dashes and underscores and start with a letter or digit`},
+	}
+
+	assert.Equal(t, want, err)
+	assert.Empty(t, result)
+}
+
+func TestParseComposeFile_WhenDirectoryGivesNoProjectName_ThenFailure(t *testing.T) {
+	// Arrange
+	dir := filepath.Join(t.TempDir(), "___")
+	assert.NoError(t, os.Mkdir(dir, 0o755))
+	file := filepath.Join(dir, "compose.yaml")
+	assert.NoError(t, os.WriteFile(file, []byte("services:\n  db:\n    image: mysql:8\n"), 0o600))
+
+	// Act
+	result, err := yaml.ParseComposeFile(file)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Empty(t, result)
+}
+
+func TestComposeFile_SetProjectName_ThenSuccess(t *testing.T) {
+	// Arrange
+	composeFile := &yaml.ComposeFile{Name: "project"}
+
+	// Act
+	err := composeFile.SetProjectName("feature_branch-2")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "feature_branch-2", composeFile.Name)
+	assert.Equal(t, "feature_branch-2-db", composeFile.ContainerName("db"))
+}
+
+func TestComposeFile_SetProjectName_WhenNameIsInvalid_ThenFailure(t *testing.T) {
+	for _, name := range []string{"Upper", "-leading", "with space", "dot.ted"} {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			composeFile := &yaml.ComposeFile{Name: "project"}
+
+			// Act
+			err := composeFile.SetProjectName(name)
+
+			// Assert
+			assert.Error(t, err)
+			assert.Equal(t, "project", composeFile.Name)
+		})
+	}
+}
+
+func TestParseComposeFile_WhenNetworkIsUndefined_ThenFailure(t *testing.T) {
+	// Arrange
+	file := "testdata/undefined-network-compose.yaml"
+
+	// Act
+	result, err := yaml.ParseComposeFile(file)
+
+	// Assert
+	want := yaml.ValidationErrors{
+		{File: file, Line: 5, Column: 9, Message: "service app refers to undefined network front"},
+	}
+
+	assert.Equal(t, want, err)
+	assert.Empty(t, result)
+}
+
+func TestParseComposeFile_WhenReferencesAreUndefined_ThenAllErrorsAreReported(t *testing.T) {
+	// Arrange
+	file := "testdata/undefined-references-compose.yaml"
+
+	// Act
+	result, err := yaml.ParseComposeFile(file)
+
+	// Assert
+	want := yaml.ValidationErrors{
+		{File: file, Line: 4, Column: 5, Message: `unknown key "restrat" in services.app`},
+		{File: file, Line: 6, Column: 7, Message: "service app refers to undefined network front"},
+		{File: file, Line: 8, Column: 7, Message: "service app depends on undefined service db"},
+		{File: file, Line: 11, Column: 9, Message: "service app refers to undefined volume data"},
+	}
+
+	assert.Equal(t, want, err)
+	assert.Empty(t, result)
+}
+
+func TestParseComposeFile_WhenVariablesAreInterpolated_ThenSuccess(t *testing.T) {
+	// Arrange
+	t.Setenv("GREETING", "hello from the shell")
+	t.Setenv("REGION", "")
+
+	// Act
+	result, err := yaml.ParseComposeFile("testdata/interpolation/compose.yaml")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "nginx:alpine", result.Services["web"].Image)
+	assert.EqualValues(t, map[string]string{
+		"GREETING": "hello from the shell",
+		"PASSWORD": "${NOT_INTERPOLATED}",
+		"REGION":   "eu-west-1",
+		"ZONE":     "",
+		"MODE":     "production",
+	}, result.Services["web"].EnvironmentVars)
+	assert.EqualValues(t, yaml.ServicePorts{{Target: 80, Published: "8080", Protocol: "tcp"}}, result.Services["web"].Ports)
+}
+
+func TestParseComposeFile_WhenRequiredVariableIsMissing_ThenFailure(t *testing.T) {
+	// Arrange
+	t.Setenv("DB_IMAGE", "")
+
+	// Act
+	result, err := yaml.ParseComposeFile("testdata/interpolation/required-compose.yaml")
+
+	// Assert
+	assert.EqualError(t, err, "testdata/interpolation/required-compose.yaml: line 3: error interpolating services.db.image: required variable DB_IMAGE is missing a value: the database image is required")
+	assert.Empty(t, result)
+}
+
+func TestParseComposeFile_WhenEnvironmentIsDeclared_ThenSuccess(t *testing.T) {
+	// Arrange
+	t.Setenv("INHERITED", "from-host")
+	absDir, _ := filepath.Abs("testdata/environment")
+
+	// Act
+	result, err := yaml.ParseComposeFile("testdata/environment/compose.yaml")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.EqualValues(t, yaml.ServiceEnvironment{
+		"DEBUG":       "true",
+		"EMPTY":       "",
+		"WITH_EQUALS": "a=b",
+		"INHERITED":   "from-host",
+	}, result.Services["list"].EnvironmentVars)
+	assert.EqualValues(t, yaml.ServiceEnvironment{
+		"ENABLED":   "true",
+		"RATIO":     "1.10",
+		"REPLICAS":  "3",
+		"INHERITED": "from-host",
+	}, result.Services["map"].EnvironmentVars)
+	assert.EqualValues(t, yaml.ServiceEnvironment{
+		"COMMON":     "common",
+		"OVERRIDDEN": "from-environment",
+		"SHARED":     "from-service",
+		"QUOTED":     "quoted value",
+	}, result.Services["files"].EnvironmentVars)
+	assert.EqualValues(t, yaml.ServiceEnvFiles{
+		{Path: filepath.Join(absDir, "common.env"), Required: true},
+		{Path: filepath.Join(absDir, "optional.env"), Required: false},
+		{Path: filepath.Join(absDir, "service.env"), Required: true},
+	}, result.Services["files"].EnvFiles)
+	assert.EqualValues(t, yaml.ServiceEnvironment{
+		"SHARED": "from-service",
+		"QUOTED": "quoted value",
+	}, result.Services["single-file"].EnvironmentVars)
+}
+
+func TestParseComposeFile_WhenRequiredEnvFileIsMissing_ThenFailure(t *testing.T) {
+	// Arrange
+
+	// Act
+	result, err := yaml.ParseComposeFile("testdata/environment/missing-env-file-compose.yaml")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "service web: error reading env file")
+	assert.Empty(t, result)
+}
+
+func TestParseComposeFiles_WhenFilesOverrideEachOther_ThenMerged(t *testing.T) {
+	// Arrange
+	basePath, _ := filepath.Abs("testdata/override/compose.yaml")
+	overridePath, _ := filepath.Abs("testdata/override/docker-compose.override.yaml")
+
+	// Act
+	result, err := yaml.ParseComposeFiles("testdata/override/compose.yaml", "testdata/override/docker-compose.override.yaml")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "override", result.Name)
+	assert.Equal(t, []string{basePath, overridePath}, result.ConfigFiles)
+	assert.ElementsMatch(t, []string{"web", "db", "cache"}, serviceNames(result.Services))
+
+	web := result.Services["web"]
+	assert.Equal(t, "nginx:alpine", web.Image)
+	assert.EqualValues(t, yaml.ServiceEnvironment{"LOG_LEVEL": "debug", "REGION": "eu"}, web.EnvironmentVars)
+	assert.EqualValues(t, yaml.ServicePorts{
+		{Target: 80, Published: "9090"},
+		{Target: 443, Published: "8443", Protocol: "tcp"},
+	}, web.Ports)
+	assert.EqualValues(t, yaml.ServiceVolumes{
+		{Type: yaml.VolumeTypeVolume, Source: "logs", Target: "/var/log/nginx"},
+		{Type: yaml.VolumeTypeBind, Source: filepath.Join(filepath.Dir(basePath), "site"), Target: "/usr/share/nginx/html"},
+	}, web.Volumes)
+	assert.EqualValues(t, yaml.ServiceDependencies{
+		"db":    {Condition: yaml.ConditionServiceStarted},
+		"cache": {Condition: yaml.ConditionServiceStarted},
+	}, web.DependsOn)
+	assert.EqualValues(t, yaml.HealthCheckTest{"CMD", "wget", "-q", "http://localhost"}, web.HealthCheck.Test)
+	assert.Equal(t, 3, web.HealthCheck.Retries)
+}
+
+func TestParseComposeFiles_WhenNoFileIsGiven_ThenFailure(t *testing.T) {
+	// Arrange
+
+	// Act
+	result, err := yaml.ParseComposeFiles()
+
+	// Assert
+	assert.Error(t, err)
+	assert.Empty(t, result)
+}
+
+func TestFindOverrideFile(t *testing.T) {
+	// Arrange
+	overridePath := filepath.Join("testdata", "override", "docker-compose.override.yaml")
+
+	// Act
+	path, found := yaml.FindOverrideFile(filepath.Join("testdata", "override"))
+	_, foundInTestdata := yaml.FindOverrideFile("testdata")
+
+	// Assert
+	assert.True(t, found)
+	assert.Equal(t, overridePath, path)
+	assert.False(t, foundInTestdata)
+}
+
+func serviceNames(services map[string]yaml.Service) []string {
+	var names []string
+	for name := range services {
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestComposeFile_YAML_WhenParsedAgain_ThenModelIsUnchanged(t *testing.T) {
+	// Arrange
+	composeFile, err := yaml.ParseComposeFile(path)
+	assert.NoError(t, err)
+
+	document, err := composeFile.YAML()
+	assert.NoError(t, err)
+
+	resolvedPath := filepath.Join(t.TempDir(), "compose.yaml")
+	assert.NoError(t, os.WriteFile(resolvedPath, document, 0o600))
+
+	// Act
+	result, err := yaml.ParseComposeFile(resolvedPath)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.EqualValues(t, composeFile.Services, result.Services)
+	assert.EqualValues(t, composeFile.Volumes, result.Volumes)
+}
+
+func TestComposeFile_YAML_WhenValuesHoldDollarSigns_ThenTheyAreEscaped(t *testing.T) {
+	// Arrange
+	composeFile, err := yaml.ParseComposeFile("testdata/command-compose.yaml")
+	assert.NoError(t, err)
+
+	document, err := composeFile.YAML()
+	assert.NoError(t, err)
+
+	resolvedPath := filepath.Join(t.TempDir(), "compose.yaml")
+	assert.NoError(t, os.WriteFile(resolvedPath, document, 0o600))
+
+	// Act
+	result, err := yaml.ParseComposeFile(resolvedPath)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, string(document), "echo $$HOME && sleep 1")
+	assert.EqualValues(t, composeFile.Services["worker"], result.Services["worker"])
+}
+
+func TestParseComposeFile_WhenSchemaIsInvalid_ThenAllErrorsAreReported(t *testing.T) {
+	// Arrange
+	file := "testdata/invalid-schema-compose.yaml"
+
+	// Act
+	result, err := yaml.ParseComposeFile(file)
+
+	// Assert
+	want := yaml.ValidationErrors{
+		{File: file, Line: 5, Column: 5, Message: `unknown key "enviroment" in services.web`},
+		{File: file, Line: 9, Column: 9, Message: `services.web.ports: invalid port "80:abc": Invalid containerPort: abc`},
+		{File: file, Line: 11, Column: 9, Message: `unknown key "publishd" in services.web.ports[2]`},
+		{File: file, Line: 12, Column: 17, Message: "services.web.ports[3].target must be an integer"},
+		{File: file, Line: 14, Column: 9, Message: `services.web.volumes: invalid volume "a:b:c:d"`},
+		{File: file, Line: 16, Column: 16, Message: "services.web.healthcheck.retries must be an integer"},
+		{File: file, Line: 17, Column: 3, Message: `invalid service name "bad name": it must contain only letters, digits, dots, dashes and underscores and start with a letter or digit`},
+		{File: file, Line: 19, Column: 3, Message: "service worker must define an image or a build"},
+		{File: file, Line: 21, Column: 15, Message: "services.worker.networks: networks must be a list or a map"},
+	}
+
+	assert.Equal(t, want, err)
+	assert.Empty(t, result)
+}
+
+func TestParseComposeFiles_WhenImageIsDefinedInOverride_ThenSuccess(t *testing.T) {
+	// Arrange
+
+	// Act
+	result, err := yaml.ParseComposeFiles("testdata/override/without-image-compose.yaml", "testdata/override/docker-compose.override.yaml")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "nginx:alpine", result.Services["web"].Image)
+}
+
+func TestFindComposeFile_WhenFileIsInParentDirectory_ThenSuccess(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+	nested := filepath.Join(root, "services", "api")
+	assert.NoError(t, os.MkdirAll(nested, 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "docker-compose.yml"), nil, 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "compose.yaml"), nil, 0o600))
+
+	// Act
+	path, err := yaml.FindComposeFile(nested)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "compose.yaml"), path)
+}
+
+func TestFindComposeFile_WhenNoFileExists_ThenFailure(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+
+	// Act
+	path, err := yaml.FindComposeFile(dir)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Empty(t, path)
+}
+
+func TestParseComposeFile_WhenCommandsAreDefined_ThenSuccess(t *testing.T) {
+	// Arrange
+
+	// Act
+	result, err := yaml.ParseComposeFile("testdata/command-compose.yaml")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.EqualValues(t, yaml.ShellCommand{"memcached", "-m", "512", "-v"}, result.Services["cache"].Command)
+	assert.Nil(t, result.Services["cache"].Entrypoint)
+	assert.EqualValues(t, yaml.Service{
+		Image:      "alpine",
+		Command:    yaml.ShellCommand{"sh", "-c", "echo $HOME && sleep 1"},
+		Entrypoint: yaml.ShellCommand{"/bin/sh", "-c", `exec "$0" "$@"`},
+		WorkingDir: "/app",
+		User:       "1000:1000",
+		Hostname:   "worker-1",
+		Tty:        true,
+		StdinOpen:  true,
+	}, result.Services["worker"])
+	assert.EqualValues(t, yaml.ShellCommand{}, result.Services["reset"].Entrypoint)
+}
+
+func TestParseComposeFile_WhenResourcesAreLimited_ThenSuccess(t *testing.T) {
+	// Arrange
+
+	// Act
+	result, err := yaml.ParseComposeFile("testdata/resources-compose.yaml")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.EqualValues(t, yaml.Service{
+		Image:          "mysql",
+		Restart:        &yaml.RestartPolicy{Name: yaml.RestartPolicyOnFailure, MaximumRetryCount: 3},
+		MemLimit:       512 * 1024 * 1024,
+		MemReservation: 256 * 1024 * 1024,
+		CPUs:           1.5,
+		CPUShares:      512,
+		PidsLimit:      100,
+		ShmSize:        64 * 1024 * 1024,
+	}, result.Services["db"])
+	assert.EqualValues(t, yaml.Service{
+		Image:   "golang",
+		Restart: &yaml.RestartPolicy{Name: yaml.RestartPolicyUnlessStopped},
+		Deploy: &yaml.Deploy{Resources: yaml.DeployResources{
+			Limits:       &yaml.ResourceLimits{CPUs: 0.5, Memory: 1024 * 1024 * 1024, Pids: 50},
+			Reservations: &yaml.ResourceLimits{Memory: 128 * 1024 * 1024},
+		}},
+	}, result.Services["api"])
+}
+
+func TestParseComposeFile_WhenResourcesAreInvalid_ThenFailure(t *testing.T) {
+	// Arrange
+	file := "testdata/invalid-resources-compose.yaml"
+
+	// Act
+	result, err := yaml.ParseComposeFile(file)
+
+	// Assert
+	want := yaml.ValidationErrors{
+		{File: file, Line: 4, Column: 14, Message: `services.db.restart: unknown restart policy "sometimes"`},
+		{File: file, Line: 5, Column: 16, Message: `services.db.mem_limit: invalid size "lots"`},
+		{File: file, Line: 6, Column: 11, Message: `services.db.cpus: invalid number of cpus "one"`},
+	}
+
+	assert.Equal(t, want, err)
+	assert.Empty(t, result)
+}
+
+func TestParseComposeFile_WhenSecurityOptionsAreDefined_ThenSuccess(t *testing.T) {
+	// Arrange
+
+	// Act
+	result, err := yaml.ParseComposeFile("testdata/security-compose.yaml")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.EqualValues(t, yaml.Service{
+		Image:      "alpine",
+		Privileged: true,
+		CapAdd:     []string{"NET_ADMIN"},
+		Sysctls:    yaml.Mapping{"net.ipv4.ip_forward": "1"},
+	}, result.Services["router"])
+	assert.EqualValues(t, yaml.Service{
+		Image:       "alpine",
+		ReadOnly:    true,
+		CapDrop:     []string{"ALL"},
+		SecurityOpt: []string{"no-new-privileges:true"},
+		Tmpfs:       yaml.StringList{"/run"},
+		Sysctls:     yaml.Mapping{"net.core.somaxconn": "1024"},
+		Ulimits: map[string]yaml.Ulimit{
+			"nproc":  {Soft: 65535, Hard: 65535},
+			"nofile": {Soft: 20000, Hard: 40000},
+		},
+	}, result.Services["locked"])
+}
+
+func TestParseComposeFile_WhenSoftUlimitExceedsHardUlimit_ThenFailure(t *testing.T) {
+	// Arrange
+	file := "testdata/invalid-ulimits-compose.yaml"
+
+	// Act
+	result, err := yaml.ParseComposeFile(file)
+
+	// Assert
+	want := yaml.ValidationErrors{
+		{File: file, Line: 6, Column: 9, Message: "services.locked.ulimits.nofile: soft limit 40000 is greater than hard limit 20000"},
+	}
+
+	assert.Equal(t, want, err)
+	assert.Empty(t, result)
+}
+
+func TestParseComposeFile_WhenLabelsAndLoggingAreDefined_ThenSuccess(t *testing.T) {
+	// Arrange
+
+	// Act
+	result, err := yaml.ParseComposeFile("testdata/labels-compose.yaml")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.EqualValues(t, yaml.Service{
+		Image: "nginx",
+		Labels: yaml.Mapping{
+			"traefik.enable":                "true",
+			"traefik.http.routers.web.rule": "Host(`example.com`)",
+		},
+		ExtraHosts:      yaml.ExtraHosts{"db.local:10.0.0.2", "cache.local:10.0.0.3"},
+		DNS:             yaml.StringList{"8.8.8.8"},
+		DNSSearch:       yaml.StringList{"example.com"},
+		Domainname:      "example.com",
+		Logging:         &yaml.Logging{Driver: "json-file", Options: map[string]string{"max-size": "10m", "max-file": "3"}},
+		StopSignal:      "SIGINT",
+		StopGracePeriod: 90 * time.Second,
+	}, result.Services["web"])
+	assert.EqualValues(t, yaml.Service{
+		Image:      "alpine",
+		Labels:     yaml.Mapping{"com.example.team": "platform", "com.example.empty": ""},
+		ExtraHosts: yaml.ExtraHosts{"api.local:10.0.0.4", "metrics.local:10.0.0.5"},
+	}, result.Services["worker"])
+}
+
+func TestParseComposeFile_WhenBuildIsDefined_ThenSuccess(t *testing.T) {
+	// Arrange
+	dir, err := filepath.Abs("testdata")
+	assert.NoError(t, err)
+	t.Setenv("DEBUG", "true")
+
+	// Act
+	result, err := yaml.ParseComposeFile("testdata/build-compose.yaml")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.EqualValues(t, yaml.Service{
+		Build: &yaml.Build{Context: filepath.Join(dir, "web")},
+	}, result.Services["web"])
+	assert.EqualValues(t, yaml.Service{
+		Image: "registry.example.com/api:dev",
+		Build: &yaml.Build{
+			Context:    filepath.Join(dir, "api"),
+			Dockerfile: "Dockerfile.dev",
+			Args:       yaml.ServiceEnvironment{"GO_VERSION": "1.18", "DEBUG": "true"},
+			Target:     "builder",
+			Labels:     yaml.Mapping{"com.example.team": "backend"},
+			CacheFrom:  []string{"registry.example.com/api:cache"},
+		},
+	}, result.Services["api"])
+	assert.EqualValues(t, yaml.Service{
+		Build: &yaml.Build{Context: dir, Dockerfile: "worker.Dockerfile"},
+	}, result.Services["worker"])
+}
+
+func TestParseComposeFile_WhenBuildIsInvalid_ThenFailure(t *testing.T) {
+	// Arrange
+	file := "testdata/invalid-build-compose.yaml"
+
+	// Act
+	result, err := yaml.ParseComposeFile(file)
+
+	// Assert
+	want := yaml.ValidationErrors{
+		{File: file, Line: 5, Column: 7, Message: `unknown key "dockerfiles" in services.web.build`},
+		{File: file, Line: 6, Column: 19, Message: "services.web.build.cache_from must be a list"},
+		{File: file, Line: 8, Column: 12, Message: "services.api.build: build must be a string or a mapping"},
+	}
+
+	assert.Equal(t, want, err)
+	assert.Empty(t, result)
+}
+
+func TestComposeFile_ImageName(t *testing.T) {
+	// Arrange
+	composeFile := &yaml.ComposeFile{
+		Name: "shop",
+		Services: map[string]yaml.Service{
+			"api": {Image: "registry.example.com/api:dev", Build: &yaml.Build{Context: "api"}},
+			"web": {Build: &yaml.Build{Context: "web"}},
+		},
+	}
+
+	// Act
+	apiImage := composeFile.ImageName("api")
+	webImage := composeFile.ImageName("web")
+
+	// Assert
+	assert.Equal(t, "registry.example.com/api:dev", apiImage)
+	assert.Equal(t, "shop-web", webImage)
+}
+
+func TestParseComposeFile_WhenPullPoliciesAreDefined_ThenSuccess(t *testing.T) {
+	// Arrange
+
+	// Act
+	result, err := yaml.ParseComposeFile("testdata/pull-policy-compose.yaml")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, yaml.PullPolicy(yaml.PullPolicyAlways), result.Services["web"].PullPolicy)
+	assert.Equal(t, yaml.PullPolicy(yaml.PullPolicyMissing), result.Services["db"].PullPolicy)
+	assert.Equal(t, yaml.PullPolicy(yaml.PullPolicyBuild), result.Services["api"].PullPolicy)
+}
+
+func TestParseComposeFile_WhenPullPolicyIsUnknown_ThenFailure(t *testing.T) {
+	// Arrange
+	file := "testdata/invalid-pull-policy-compose.yaml"
+
+	// Act
+	result, err := yaml.ParseComposeFile(file)
+
+	// Assert
+	want := yaml.ValidationErrors{
+		{File: file, Line: 4, Column: 18, Message: `services.web.pull_policy: unknown pull policy "sometimes"`},
+	}
+
+	assert.Equal(t, want, err)
+	assert.Empty(t, result)
+}
+
+func TestParseComposeFile_WhenPullPolicyIsBuildWithoutBuild_ThenFailure(t *testing.T) {
+	// Arrange
+	file := "testdata/pull-policy-without-build-compose.yaml"
+
+	// Act
+	result, err := yaml.ParseComposeFile(file)
+
+	// Assert
+	want := yaml.ValidationErrors{
+		{File: file, Line: 2, Column: 3, Message: "service web has pull policy build but does not define a build"},
+	}
+
+	assert.Equal(t, want, err)
+	assert.Empty(t, result)
+}